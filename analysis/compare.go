@@ -0,0 +1,285 @@
+/*
+Package analysis works out how the films of two or more letterboxd members
+overlap
+*/
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+)
+
+const (
+	// MaxCompareUsers is the most users that can be compared at once. Each one
+	// means scraping a full watched history
+	MaxCompareUsers = 10
+	// DefaultMinSharedRatings is how many films a pair of users must have both
+	// rated before they get a compatibility score
+	DefaultMinSharedRatings = 5
+)
+
+// UserFilms is what's compared for each user
+type UserFilms struct {
+	User      string             `json:"user"`
+	Watched   []*letterboxd.Film `json:"watched"` // Film.UserRating is the user's rating
+	Watchlist []*letterboxd.Film `json:"watchlist"`
+}
+
+// CompareOpt is the options for comparing users
+type CompareOpt struct {
+	MinSharedRatings int // Films a pair must have both rated to be scored. Defaults to DefaultMinSharedRatings
+}
+
+// Comparison is how the films of a group of users overlap
+type Comparison struct {
+	Users            []string          `json:"users"`
+	WatchedByAll     []*SharedFilm     `json:"watched_by_all"`    // Films every user has watched, in the first user's order
+	WatchlistWatched []*WatchlistMatch `json:"watchlist_watched"` // Films on someone's watchlist that someone else has watched
+	Compatibility    []*Compatibility  `json:"compatibility"`     // One for each pair of users
+}
+
+// SharedFilm is a film more than one user has watched
+type SharedFilm struct {
+	Film    *letterboxd.Film   `json:"film"`              // Without any one user's rating
+	Ratings map[string]float64 `json:"ratings,omitempty"` // Stars, by user. Users who didn't rate it are left out
+}
+
+// WatchlistMatch is a film on User's watchlist that others have already seen,
+// and can vouch for (or warn about)
+type WatchlistMatch struct {
+	Film      *letterboxd.Film   `json:"film"`
+	User      string             `json:"user"` // Whose watchlist it's on
+	WatchedBy []string           `json:"watched_by"`
+	Ratings   map[string]float64 `json:"ratings,omitempty"` // Stars, by user. Users who didn't rate it are left out
+}
+
+// Compatibility is how alike the taste of two users is
+type Compatibility struct {
+	Users         [2]string `json:"users"`
+	SharedWatched int       `json:"shared_watched"`
+	SharedRated   int       `json:"shared_rated"`
+	// Score runs from 0 (opposite taste) to 100 (the same taste), from how
+	// closely their ratings of the same films move together. nil if they
+	// haven't rated enough of the same films, or one gave them all the same
+	// rating
+	Score *float64 `json:"score"`
+}
+
+func (c *CompareOpt) Validate() error {
+	if c.MinSharedRatings < 0 {
+		return errors.New("MinSharedRatings must be positive")
+	}
+	return nil
+}
+
+// ValidateUsers checks there are enough users to compare, but not too many,
+// and that none are repeated
+func ValidateUsers(users []string) error {
+	if len(users) < 2 {
+		return errors.New("at least 2 users are needed to compare")
+	}
+	if len(users) > MaxCompareUsers {
+		return fmt.Errorf("at most %v users can be compared at once", MaxCompareUsers)
+	}
+	seen := map[string]bool{}
+	for _, user := range users {
+		if user == "" {
+			return errors.New("user can't be empty")
+		}
+		if seen[user] {
+			return fmt.Errorf("duplicate user: %v", user)
+		}
+		seen[user] = true
+	}
+	return nil
+}
+
+// FetchUserFilms gets the watched films and watchlist of a user. Only the film
+// previews on each page are read. They carry the user's ratings, which is all
+// a comparison needs, and looking up the details of every film in a big
+// watched history would take thousands of requests
+func FetchUserFilms(ctx context.Context, client *letterboxd.ScrapeClient, user string) (*UserFilms, error) {
+	watched, err := client.Film.PreviewPages(ctx, user+"/films")
+	if err != nil {
+		return nil, fmt.Errorf("%v watched: %w", user, err)
+	}
+	watchlist, err := client.Film.PreviewPages(ctx, user+"/watchlist")
+	if err != nil {
+		return nil, fmt.Errorf("%v watchlist: %w", user, err)
+	}
+	return &UserFilms{User: user, Watched: watched, Watchlist: watchlist}, nil
+}
+
+// CompareUsers fetches the films of each user, then compares them
+func CompareUsers(ctx context.Context, client *letterboxd.ScrapeClient, usernames []string, opt *CompareOpt) (*Comparison, error) {
+	if err := ValidateUsers(usernames); err != nil {
+		return nil, err
+	}
+	all := make([]*UserFilms, len(usernames))
+	errs := make([]error, len(usernames))
+	var wg sync.WaitGroup
+	for i, user := range usernames {
+		wg.Add(1)
+		go func(i int, user string) {
+			defer wg.Done()
+			all[i], errs[i] = FetchUserFilms(ctx, client, user)
+		}(i, user)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return Compare(all, opt)
+}
+
+// Compare works out the overlap between users' films. Films are matched by
+// slug
+func Compare(users []*UserFilms, opt *CompareOpt) (*Comparison, error) {
+	if opt == nil {
+		opt = &CompareOpt{}
+	}
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	minShared := opt.MinSharedRatings
+	if minShared == 0 {
+		minShared = DefaultMinSharedRatings
+	}
+	names := make([]string, 0, len(users))
+	for _, u := range users {
+		names = append(names, u.User)
+	}
+	if err := ValidateUsers(names); err != nil {
+		return nil, err
+	}
+
+	// Each user's watched films, by slug
+	watched := make([]map[string]*letterboxd.Film, len(users))
+	for i, u := range users {
+		watched[i] = make(map[string]*letterboxd.Film, len(u.Watched))
+		for _, film := range u.Watched {
+			watched[i][film.Slug] = film
+		}
+	}
+
+	c := &Comparison{
+		Users:            names,
+		WatchedByAll:     []*SharedFilm{},
+		WatchlistWatched: []*WatchlistMatch{},
+		Compatibility:    []*Compatibility{},
+	}
+	seen := map[string]bool{}
+	for _, film := range users[0].Watched {
+		if seen[film.Slug] {
+			continue
+		}
+		seen[film.Slug] = true
+		shared := &SharedFilm{Film: withoutUserData(film), Ratings: map[string]float64{}}
+		for i, u := range users {
+			w, ok := watched[i][film.Slug]
+			if !ok {
+				shared = nil
+				break
+			}
+			if w.UserRating > 0 {
+				shared.Ratings[u.User] = w.UserRating
+			}
+		}
+		if shared != nil {
+			c.WatchedByAll = append(c.WatchedByAll, shared)
+		}
+	}
+
+	for i, u := range users {
+		for _, film := range u.Watchlist {
+			match := &WatchlistMatch{Film: withoutUserData(film), User: u.User, WatchedBy: []string{}, Ratings: map[string]float64{}}
+			for j, other := range users {
+				if i == j {
+					continue
+				}
+				if w, ok := watched[j][film.Slug]; ok {
+					match.WatchedBy = append(match.WatchedBy, other.User)
+					if w.UserRating > 0 {
+						match.Ratings[other.User] = w.UserRating
+					}
+				}
+			}
+			if len(match.WatchedBy) > 0 {
+				c.WatchlistWatched = append(c.WatchlistWatched, match)
+			}
+		}
+	}
+
+	for i := range users {
+		for j := i + 1; j < len(users); j++ {
+			c.Compatibility = append(c.Compatibility, compatibility(users[i], users[j], watched[i], watched[j], minShared))
+		}
+	}
+	return c, nil
+}
+
+// compatibility scores a pair of users by the Pearson correlation of their
+// ratings of the films they've both rated
+func compatibility(a, b *UserFilms, aWatched, bWatched map[string]*letterboxd.Film, minShared int) *Compatibility {
+	c := &Compatibility{Users: [2]string{a.User, b.User}}
+	var xs, ys []float64
+	for slug, af := range aWatched {
+		bf, ok := bWatched[slug]
+		if !ok {
+			continue
+		}
+		c.SharedWatched++
+		if af.UserRating > 0 && bf.UserRating > 0 {
+			xs = append(xs, af.UserRating)
+			ys = append(ys, bf.UserRating)
+		}
+	}
+	c.SharedRated = len(xs)
+	if c.SharedRated < minShared {
+		return c
+	}
+	if r, ok := pearson(xs, ys); ok {
+		score := math.Round((r+1)*50*10) / 10
+		c.Score = &score
+	}
+	return c
+}
+
+// pearson returns the correlation of xs and ys. ok is false if either has no
+// variance
+func pearson(xs, ys []float64) (r float64, ok bool) {
+	n := float64(len(xs))
+	var sx, sy float64
+	for i := range xs {
+		sx += xs[i]
+		sy += ys[i]
+	}
+	mx, my := sx/n, sy/n
+	var cov, vx, vy float64
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		cov += dx * dy
+		vx += dx * dx
+		vy += dy * dy
+	}
+	if vx == 0 || vy == 0 {
+		return 0, false
+	}
+	return cov / math.Sqrt(vx*vy), true
+}
+
+// withoutUserData copies film, dropping the rating and like that belong to
+// whoever's page it came from
+func withoutUserData(film *letterboxd.Film) *letterboxd.Film {
+	f := *film
+	f.UserRating = 0
+	f.UserLiked = false
+	return &f
+}
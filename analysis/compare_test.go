@@ -0,0 +1,120 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/stretchr/testify/require"
+)
+
+func film(slug string, rating float64) *letterboxd.Film {
+	return &letterboxd.Film{Slug: slug, Title: slug, Target: "/film/" + slug + "/", UserRating: rating}
+}
+
+func testUsers() []*UserFilms {
+	return []*UserFilms{
+		{
+			User: "alice",
+			Watched: []*letterboxd.Film{
+				film("alien", 5), film("the-thing", 4.5), film("halloween", 3), film("cats", 1), film("heat", 4), film("clue", 0),
+			},
+			Watchlist: []*letterboxd.Film{film("jaws", 0)},
+		},
+		{
+			User: "bob",
+			Watched: []*letterboxd.Film{
+				film("the-thing", 5), film("alien", 4.5), film("cats", 0.5), film("halloween", 2.5), film("heat", 4), film("clue", 3), film("jaws", 4),
+			},
+			Watchlist: []*letterboxd.Film{film("ran", 0), film("clue", 0)},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	c, err := Compare(testUsers(), nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice", "bob"}, c.Users)
+
+	require.Len(t, c.WatchedByAll, 6)
+	require.Equal(t, "alien", c.WatchedByAll[0].Film.Slug)
+	require.Zero(t, c.WatchedByAll[0].Film.UserRating)
+	require.Equal(t, map[string]float64{"alice": 5, "bob": 4.5}, c.WatchedByAll[0].Ratings)
+	// Unrated films are still shared, just without a rating
+	require.Equal(t, map[string]float64{"bob": 3}, c.WatchedByAll[5].Ratings)
+
+	require.Len(t, c.WatchlistWatched, 2)
+	require.Equal(t, "jaws", c.WatchlistWatched[0].Film.Slug)
+	require.Equal(t, "alice", c.WatchlistWatched[0].User)
+	require.Equal(t, []string{"bob"}, c.WatchlistWatched[0].WatchedBy)
+	require.Equal(t, map[string]float64{"bob": 4}, c.WatchlistWatched[0].Ratings)
+	require.Equal(t, "clue", c.WatchlistWatched[1].Film.Slug)
+	require.Equal(t, "bob", c.WatchlistWatched[1].User)
+	require.Empty(t, c.WatchlistWatched[1].Ratings)
+
+	require.Len(t, c.Compatibility, 1)
+	compat := c.Compatibility[0]
+	require.Equal(t, [2]string{"alice", "bob"}, compat.Users)
+	require.Equal(t, 6, compat.SharedWatched)
+	require.Equal(t, 5, compat.SharedRated)
+	require.NotNil(t, compat.Score)
+	require.Greater(t, *compat.Score, 90.0)
+	require.LessOrEqual(t, *compat.Score, 100.0)
+}
+
+func TestCompareNotEnoughRatings(t *testing.T) {
+	c, err := Compare(testUsers(), &CompareOpt{MinSharedRatings: 6})
+	require.NoError(t, err)
+	require.Equal(t, 5, c.Compatibility[0].SharedRated)
+	require.Nil(t, c.Compatibility[0].Score)
+}
+
+func TestCompareOppositeTaste(t *testing.T) {
+	users := []*UserFilms{
+		{User: "alice", Watched: []*letterboxd.Film{film("a", 5), film("b", 4), film("c", 3), film("d", 2), film("e", 1)}},
+		{User: "bob", Watched: []*letterboxd.Film{film("a", 1), film("b", 2), film("c", 3), film("d", 4), film("e", 5)}},
+		{User: "carol", Watched: []*letterboxd.Film{film("a", 3), film("b", 3), film("c", 3), film("d", 3), film("e", 3)}},
+	}
+	c, err := Compare(users, nil)
+	require.NoError(t, err)
+	require.Len(t, c.WatchedByAll, 5)
+	require.Len(t, c.Compatibility, 3)
+	require.Equal(t, [2]string{"alice", "bob"}, c.Compatibility[0].Users)
+	require.Equal(t, 0.0, *c.Compatibility[0].Score)
+	// Carol rates everything the same, so there's nothing to correlate
+	require.Nil(t, c.Compatibility[1].Score)
+	require.Nil(t, c.Compatibility[2].Score)
+}
+
+func TestValidateUsers(t *testing.T) {
+	require.NoError(t, ValidateUsers([]string{"alice", "bob"}))
+	require.Error(t, ValidateUsers([]string{"alice"}))
+	require.Error(t, ValidateUsers([]string{"alice", "alice"}))
+	require.Error(t, ValidateUsers([]string{"alice", ""}))
+	require.Error(t, ValidateUsers(make([]string, MaxCompareUsers+1)))
+}
+
+func newTestClient(baseURL string) *letterboxd.ScrapeClient {
+	client := letterboxd.NewScrapeClient(nil, &letterboxd.ClientOptions{Rate: -1, Concurrency: -1})
+	client.BaseURL = baseURL
+	return client
+}
+
+func TestCompareUsers(t *testing.T) {
+	var filmRequests int32
+	srv := newLetterboxdServer(t, testUsers(), &filmRequests)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+	c, err := CompareUsers(context.Background(), client, []string{"bob", "alice"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"bob", "alice"}, c.Users)
+	require.Equal(t, "the-thing", c.WatchedByAll[0].Film.Slug)
+	require.Equal(t, map[string]float64{"bob": 5, "alice": 4.5}, c.WatchedByAll[0].Ratings)
+	// Ratings come from the grids, so no film pages are needed
+	require.Zero(t, atomic.LoadInt32(&filmRequests))
+
+	_, err = CompareUsers(context.Background(), client, []string{"bob", "nobody"}, nil)
+	require.True(t, errors.Is(err, letterboxd.ErrNotFound))
+}
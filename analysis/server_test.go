@@ -0,0 +1,63 @@
+package analysis
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/stretchr/testify/require"
+)
+
+// newLetterboxdServer serves the watched films and watchlist of each user as
+// a single page poster grid, the way letterboxd shows them. Every film page
+// is Sweetback's, and requests for them are counted in filmRequests
+func newLetterboxdServer(t *testing.T, users []*UserFilms, filmRequests *int32) *httptest.Server {
+	byUser := map[string]*UserFilms{}
+	for _, u := range users {
+		byUser[u.User] = u
+	}
+	film, err := os.ReadFile("../letterboxd/testdata/film/sweetback.html")
+	require.NoError(t, err)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/film/") {
+			atomic.AddInt32(filmRequests, 1)
+			w.Write(film) // nolint:errcheck
+			return
+		}
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		u, ok := byUser[parts[0]]
+		if !ok || len(parts) != 4 || parts[2] != "page" || parts[3] != "1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch parts[1] {
+		case "films":
+			fmt.Fprint(w, posterGrid(u.Watched))
+		case "watchlist":
+			fmt.Fprint(w, posterGrid(u.Watchlist))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func posterGrid(films []*letterboxd.Film) string {
+	var b strings.Builder
+	b.WriteString("<html><body><ul>")
+	for _, film := range films {
+		fmt.Fprintf(&b, `<li class="poster-container"><div class="film-poster" data-film-slug="/film/%[1]s/" data-target-link="/film/%[1]s/"><img class="image" alt="%[2]s"/></div>`,
+			film.Slug, html.EscapeString(film.Title))
+		if film.UserRating > 0 {
+			fmt.Fprintf(&b, `<p class="poster-viewingdata"><span class="rating rated-%v"></span></p>`, int(film.UserRating*2))
+		}
+		b.WriteString("</li>")
+	}
+	b.WriteString("</ul></body></html>")
+	return b.String()
+}
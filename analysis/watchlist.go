@@ -0,0 +1,199 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+)
+
+// IntersectOpt filters the films found on users' watchlists
+type IntersectOpt struct {
+	MinUsers       int      // Watchlists a film must be on. Defaults to every one of them
+	MinRuntime     int      // Minutes. 0 means no limit
+	MaxRuntime     int      // Minutes. 0 means no limit. Films with an unknown runtime are dropped
+	Genres         []string // Films must be in every one of these genres, by name or slug, like 'Science Fiction' or 'science-fiction'
+	ExcludeGenres  []string // Films must not be in any of these genres
+	ExcludeWatched bool     // Drop films any of the users has already watched
+}
+
+// WatchlistFilm is a film on more than one watchlist
+type WatchlistFilm struct {
+	Film  *letterboxd.Film `json:"film"`
+	Users []string         `json:"users"` // Whose watchlists it's on
+}
+
+func (o *IntersectOpt) Validate() error {
+	if o.MinUsers < 0 {
+		return errors.New("MinUsers must be positive")
+	}
+	if o.MinRuntime < 0 || o.MaxRuntime < 0 {
+		return errors.New("runtimes must be positive")
+	}
+	if o.MaxRuntime != 0 && o.MinRuntime > o.MaxRuntime {
+		return errors.New("MinRuntime can't be more than MaxRuntime")
+	}
+	return nil
+}
+
+// IntersectWatchlists fetches each user's watchlist, and their watched films
+// if those are to be excluded, then intersects them. Films are matched using
+// just the previews on each page, and only the films left over have their
+// details looked up for the runtime and genre filters
+func IntersectWatchlists(ctx context.Context, client *letterboxd.ScrapeClient, usernames []string, opt *IntersectOpt) ([]*WatchlistFilm, error) {
+	if err := ValidateUsers(usernames); err != nil {
+		return nil, err
+	}
+	if opt == nil {
+		opt = &IntersectOpt{}
+	}
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	all := make([]*UserFilms, len(usernames))
+	errs := make([]error, len(usernames))
+	var wg sync.WaitGroup
+	for i, user := range usernames {
+		all[i] = &UserFilms{User: user}
+		wg.Add(1)
+		go func(u *UserFilms, err *error) {
+			defer wg.Done()
+			if u.Watchlist, *err = client.Film.PreviewPages(ctx, u.User+"/watchlist"); *err != nil {
+				*err = fmt.Errorf("%v watchlist: %w", u.User, *err)
+				return
+			}
+			if opt.ExcludeWatched {
+				if u.Watched, *err = client.Film.PreviewPages(ctx, u.User+"/films"); *err != nil {
+					*err = fmt.Errorf("%v watched: %w", u.User, *err)
+				}
+			}
+		}(all[i], &errs[i])
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	found := intersect(all, opt)
+	films := make([]*letterboxd.Film, 0, len(found))
+	for _, wf := range found {
+		films = append(films, wf.Film)
+	}
+	if err := client.Film.EnhanceFilmList(ctx, &films); err != nil {
+		return nil, err
+	}
+	return filterWatchlistFilms(found, opt), nil
+}
+
+// Intersect returns the films on at least MinUsers of the users' watchlists
+// that pass the filters, best average rating first. Films are matched by slug
+func Intersect(users []*UserFilms, opt *IntersectOpt) ([]*WatchlistFilm, error) {
+	if opt == nil {
+		opt = &IntersectOpt{}
+	}
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	return filterWatchlistFilms(intersect(users, opt), opt), nil
+}
+
+// intersect returns the films on at least MinUsers of the users' watchlists
+// that none of them have watched, if that was asked for, in the order they
+// were found
+func intersect(users []*UserFilms, opt *IntersectOpt) []*WatchlistFilm {
+	minUsers := opt.MinUsers
+	if minUsers == 0 || minUsers > len(users) {
+		minUsers = len(users)
+	}
+	watched := map[string]bool{}
+	if opt.ExcludeWatched {
+		for _, u := range users {
+			for _, film := range u.Watched {
+				watched[film.Slug] = true
+			}
+		}
+	}
+
+	found := map[string]*WatchlistFilm{}
+	var order []string
+	for _, u := range users {
+		for _, film := range u.Watchlist {
+			wf, ok := found[film.Slug]
+			if !ok {
+				wf = &WatchlistFilm{Film: film}
+				found[film.Slug] = wf
+				order = append(order, film.Slug)
+			}
+			// A film can't be on the same watchlist twice, but pages can
+			// overlap if the watchlist changes while it's scraped
+			if len(wf.Users) == 0 || wf.Users[len(wf.Users)-1] != u.User {
+				wf.Users = append(wf.Users, u.User)
+			}
+		}
+	}
+
+	ret := []*WatchlistFilm{}
+	for _, slug := range order {
+		wf := found[slug]
+		if len(wf.Users) < minUsers || watched[slug] {
+			continue
+		}
+		ret = append(ret, wf)
+	}
+	return ret
+}
+
+// filterWatchlistFilms drops the films that fail the runtime and genre
+// filters, then sorts the rest best average rating first
+func filterWatchlistFilms(films []*WatchlistFilm, opt *IntersectOpt) []*WatchlistFilm {
+	ret := []*WatchlistFilm{}
+	for _, wf := range films {
+		if opt.matches(wf.Film) {
+			ret = append(ret, wf)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		a, b := ret[i], ret[j]
+		if a.Film.AverageRating != b.Film.AverageRating {
+			return a.Film.AverageRating > b.Film.AverageRating
+		}
+		return len(a.Users) > len(b.Users)
+	})
+	return ret
+}
+
+// matches reports if a film passes the runtime and genre filters
+func (o *IntersectOpt) matches(film *letterboxd.Film) bool {
+	if o.MinRuntime > 0 && film.RuntimeMinutes < o.MinRuntime {
+		return false
+	}
+	if o.MaxRuntime > 0 && (film.RuntimeMinutes == 0 || film.RuntimeMinutes > o.MaxRuntime) {
+		return false
+	}
+	genres := map[string]bool{}
+	for _, genre := range film.Genres {
+		genres[genreSlug(genre)] = true
+	}
+	for _, genre := range o.Genres {
+		if !genres[genreSlug(genre)] {
+			return false
+		}
+	}
+	for _, genre := range o.ExcludeGenres {
+		if genres[genreSlug(genre)] {
+			return false
+		}
+	}
+	return true
+}
+
+// genreSlug turns a genre name like 'Science Fiction' in to the slug used in
+// letterboxd URLs, like 'science-fiction'
+func genreSlug(genre string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(genre)), " ", "-")
+}
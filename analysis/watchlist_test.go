@@ -0,0 +1,98 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/stretchr/testify/require"
+)
+
+func detailedFilm(slug string, rating float64, runtime int, genres ...string) *letterboxd.Film {
+	return &letterboxd.Film{Slug: slug, Title: slug, AverageRating: rating, RuntimeMinutes: runtime, Genres: genres}
+}
+
+func watchlistUsers() []*UserFilms {
+	alien := detailedFilm("alien", 4.3, 117, "Horror", "Science Fiction")
+	heat := detailedFilm("heat", 4.2, 170, "Crime", "Thriller")
+	clue := detailedFilm("clue", 3.8, 94, "Comedy", "Mystery")
+	jaws := detailedFilm("jaws", 4.0, 124, "Thriller", "Horror")
+	cats := detailedFilm("cats", 1.4, 110, "Music", "Fantasy")
+	return []*UserFilms{
+		{User: "alice", Watchlist: []*letterboxd.Film{clue, jaws, alien, cats}},
+		{User: "bob", Watchlist: []*letterboxd.Film{alien, clue, heat, cats}, Watched: []*letterboxd.Film{jaws}},
+		{User: "carol", Watchlist: []*letterboxd.Film{cats, clue, alien, heat}},
+	}
+}
+
+func slugs(films []*WatchlistFilm) []string {
+	ret := []string{}
+	for _, f := range films {
+		ret = append(ret, f.Film.Slug)
+	}
+	return ret
+}
+
+func TestIntersect(t *testing.T) {
+	films, err := Intersect(watchlistUsers(), nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"alien", "clue", "cats"}, slugs(films))
+	require.Equal(t, []string{"alice", "bob", "carol"}, films[0].Users)
+
+	films, err = Intersect(watchlistUsers(), &IntersectOpt{MinUsers: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"alien", "heat", "clue", "cats"}, slugs(films))
+
+	films, err = Intersect(watchlistUsers(), &IntersectOpt{MinUsers: 1, ExcludeWatched: true})
+	require.NoError(t, err)
+	require.Equal(t, []string{"alien", "heat", "clue", "cats"}, slugs(films))
+}
+
+func TestIntersectFilters(t *testing.T) {
+	tests := map[string]struct {
+		opt  IntersectOpt
+		want []string
+	}{
+		"max-runtime":    {opt: IntersectOpt{MinUsers: 1, MaxRuntime: 120}, want: []string{"alien", "clue", "cats"}},
+		"min-runtime":    {opt: IntersectOpt{MinUsers: 1, MinRuntime: 120}, want: []string{"heat", "jaws"}},
+		"genre":          {opt: IntersectOpt{MinUsers: 1, Genres: []string{"horror"}}, want: []string{"alien", "jaws"}},
+		"genre-name":     {opt: IntersectOpt{MinUsers: 1, Genres: []string{"Science Fiction"}}, want: []string{"alien"}},
+		"genre-slug":     {opt: IntersectOpt{MinUsers: 1, Genres: []string{"science-fiction"}}, want: []string{"alien"}},
+		"exclude-genres": {opt: IntersectOpt{MinUsers: 1, ExcludeGenres: []string{"horror", "music"}}, want: []string{"heat", "clue"}},
+	}
+	for name, tt := range tests {
+		films, err := Intersect(watchlistUsers(), &tt.opt)
+		require.NoError(t, err, name)
+		require.Equal(t, tt.want, slugs(films), name)
+	}
+
+	_, err := Intersect(watchlistUsers(), &IntersectOpt{MinRuntime: 120, MaxRuntime: 90})
+	require.Error(t, err)
+}
+
+func TestIntersectWatchlists(t *testing.T) {
+	var filmRequests int32
+	srv := newLetterboxdServer(t, watchlistUsers(), &filmRequests)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+	films, err := IntersectWatchlists(context.Background(), client, []string{"alice", "bob"}, &IntersectOpt{ExcludeWatched: true})
+	require.NoError(t, err)
+	// Every film page is Sweetback's, so they tie on rating and keep the order
+	// they were found in
+	require.Equal(t, []string{"clue", "alien", "cats"}, slugs(films))
+	require.NotEmpty(t, films[0].Film.Genres)
+	// Only the 3 films left over were looked up, with a film and themes page each
+	require.Equal(t, int32(6), atomic.LoadInt32(&filmRequests))
+
+	films, err = IntersectWatchlists(context.Background(), client, []string{"alice", "bob"}, &IntersectOpt{Genres: []string{"horror"}})
+	require.NoError(t, err)
+	require.Empty(t, films)
+
+	_, err = IntersectWatchlists(context.Background(), client, []string{"alice", "nobody"}, nil)
+	require.True(t, errors.Is(err, letterboxd.ErrNotFound))
+
+	_, err = IntersectWatchlists(context.Background(), client, []string{"alice"}, nil)
+	require.Error(t, err)
+}
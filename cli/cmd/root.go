@@ -25,6 +25,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/apex/log"
 	"github.com/apex/log/handlers/cli"
@@ -36,10 +38,10 @@ import (
 )
 
 var (
-	cfgFile string
-	client  *letterboxd.ScrapeClient
-	ctx     context.Context
-	Verbose bool
+	cfgFile   string
+	client    *letterboxd.ScrapeClient
+	filmStore *letterboxd.BoltFilmStore
+	Verbose   bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -54,14 +56,76 @@ var rootCmd = &cobra.Command{
 			log.SetLevel(log.DebugLevel)
 		}
 		log.SetHandler(cli.Default)
-		client = letterboxd.NewScrapeClient(nil)
+		var err error
+		client, err = newScrapeClient()
+		cobra.CheckErr(err)
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		if filmStore != nil {
+			cobra.CheckErr(filmStore.Close())
+		}
 	},
 }
 
+// newScrapeClient builds the scrape client from the flags and config file
+func newScrapeClient() (*letterboxd.ScrapeClient, error) {
+	opts := &letterboxd.ClientOptions{
+		Retry: &letterboxd.RetryOptions{
+			MaxAttempts: viper.GetInt("retry-attempts"),
+			Budget:      viper.GetInt("retry-budget"),
+		},
+		Rate:        viper.GetFloat64("rate"),
+		Burst:       viper.GetInt("burst"),
+		Concurrency: viper.GetInt("concurrency"),
+	}
+	// Per-host budgets only make sense in the config file, like:
+	// host-rates:
+	//   letterboxd.com: 2
+	if err := viper.UnmarshalKey("host-rates", &opts.HostRates); err != nil {
+		return nil, err
+	}
+	// The catalog of curated lists can be swapped out with a YAML or JSON file
+	if path := viper.GetString("list-catalog"); path != "" {
+		catalog, err := letterboxd.LoadListCatalog(path)
+		if err != nil {
+			return nil, err
+		}
+		opts.Catalog = catalog
+	}
+	// Films that have already been looked up are kept here between runs
+	if path := viper.GetString("film-store"); path != "" {
+		var err error
+		filmStore, err = letterboxd.NewBoltFilmStore(path)
+		if err != nil {
+			return nil, err
+		}
+		log.WithField("path", path).Debug("Using film store")
+		opts.FilmStore = filmStore
+		opts.FilmStoreMaxAge = viper.GetDuration("film-store-max-age")
+	}
+	if viper.GetBool("cache") {
+		if dir := viper.GetString("cache-dir"); dir != "" {
+			fc, err := letterboxd.NewFileCache(dir)
+			if err != nil {
+				return nil, err
+			}
+			log.WithField("dir", dir).Debug("Using file cache")
+			opts.Cache = fc
+		} else {
+			log.Debug("Using memory cache")
+			opts.Cache = letterboxd.NewMemoryCache(0)
+		}
+	}
+	return letterboxd.NewScrapeClient(nil, opts), nil
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	cobra.CheckErr(rootCmd.Execute())
+	// Cancel any in flight scrapes on Ctrl-C, instead of leaving them running
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	cobra.CheckErr(rootCmd.ExecuteContext(ctx))
 }
 
 func init() {
@@ -76,6 +140,19 @@ func init() {
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.PersistentFlags().BoolVarP(&Verbose, "verbose", "v", false, "Verbose logging")
+	rootCmd.PersistentFlags().Bool("cache", false, "Cache pages fetched from letterboxd.com")
+	rootCmd.PersistentFlags().String("cache-dir", "", "Directory to store the cache in. Uses an in-memory cache if not set")
+	rootCmd.PersistentFlags().Int("retry-attempts", letterboxd.DefaultRetryAttempts, "Times to try each request before giving up. Use 1 to disable retries")
+	rootCmd.PersistentFlags().Int("retry-budget", 0, "Total retries allowed for the whole run. 0 means no limit")
+	rootCmd.PersistentFlags().Float64("rate", letterboxd.DefaultRate, "Requests per second to send to letterboxd.com. Use a negative number for no limit")
+	rootCmd.PersistentFlags().Int("burst", letterboxd.DefaultBurst, "Requests allowed in a burst above the rate")
+	rootCmd.PersistentFlags().Int("concurrency", letterboxd.DefaultConcurrency, "Requests to have in flight at once. Use a negative number for no limit")
+	rootCmd.PersistentFlags().String("list-catalog", "", "YAML or JSON file with the curated list catalog. Uses the built in catalog if not set")
+	rootCmd.PersistentFlags().String("film-store", "", "Database file to keep looked up films in between runs. Films are fetched every time if not set")
+	rootCmd.PersistentFlags().Duration("film-store-max-age", letterboxd.DefaultFilmStoreMaxAge, "How long a stored film is used before it is fetched again")
+	for _, name := range []string{"cache", "cache-dir", "retry-attempts", "retry-budget", "rate", "burst", "concurrency", "list-catalog", "film-store", "film-store-max-age"} {
+		cobra.CheckErr(viper.BindPFlag(name, rootCmd.PersistentFlags().Lookup(name)))
+	}
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -22,7 +22,6 @@ THE SOFTWARE.
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"sync/atomic"
 
@@ -45,17 +44,25 @@ var batchCmd = &cobra.Command{
 		cobra.CheckErr(err)
 		lists, err := letterboxd.ParseListArgs(listsA)
 		cobra.CheckErr(err)
+		listGroups, err := cmd.Flags().GetStringArray("list-group")
+		cobra.CheckErr(err)
 
 		// Get Watch lists
 		watchLists, err := cmd.Flags().GetStringArray("watchlist")
 		cobra.CheckErr(err)
 
+		expr, err := cmd.Flags().GetString("expr")
+		cobra.CheckErr(err)
+
 		filmOpts := &letterboxd.FilmBatchOpts{
-			Watched:   userWatched,
-			Lists:     lists,
-			WatchList: watchLists,
+			Watched:    userWatched,
+			Lists:      lists,
+			ListGroups: listGroups,
+			WatchList:  watchLists,
+			Expression: expr,
 		}
-		ctx := context.Background()
+		cobra.CheckErr(filmOpts.Validate())
+		ctx := cmd.Context()
 		filmC := make(chan *letterboxd.Film)
 		done := make(chan error)
 		count := int64(0)
@@ -72,13 +79,12 @@ var batchCmd = &cobra.Command{
 				atomic.AddInt64(&count, 1)
 			case err := <-done:
 				if err != nil {
-					log.WithError(err).Error("Error batch streaming watched")
+					log.WithError(err).Fatal("Error batch streaming watched")
 				} else {
 					log.Info("Finished")
 					log.Infof("Total Count: %d", count)
 					return
 				}
-			default:
 			}
 		}
 	},
@@ -94,7 +100,9 @@ func init() {
 	// batchCmd.PersistentFlags().String("foo", "", "A help for foo")
 	batchCmd.PersistentFlags().StringArray("watched", []string{}, "Watched films for a given user")
 	batchCmd.PersistentFlags().StringArray("list", []string{}, "User list in the format of {username}/{list-slug}")
+	batchCmd.PersistentFlags().StringArray("list-group", []string{}, "Lists in a group from the list catalog, like 'horror' or 'canon'")
 	batchCmd.PersistentFlags().StringArray("watchlist", []string{}, "Films on a given users Watch List")
+	batchCmd.PersistentFlags().String("expr", "", "Combine sources with set operators instead, like 'list:dave/imdb-top-250 - watched:alice'. Can't be used with the other source flags")
 
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
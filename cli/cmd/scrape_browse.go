@@ -0,0 +1,105 @@
+/*
+Copyright © 2022 Drew Stinnett <drew@drewlink.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// browseCmd represents the browse command
+var browseCmd = &cobra.Command{
+	Use:   "browse",
+	Short: "Stream films matching a set of filters, like genre and decade",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		opt := &letterboxd.BrowseOpt{}
+		var err error
+		opt.Genres, err = cmd.Flags().GetStringArray("genre")
+		cobra.CheckErr(err)
+		opt.ExcludeGenres, err = cmd.Flags().GetStringArray("exclude-genre")
+		cobra.CheckErr(err)
+		opt.Decade, err = cmd.Flags().GetInt("decade")
+		cobra.CheckErr(err)
+		opt.Year, err = cmd.Flags().GetInt("year")
+		cobra.CheckErr(err)
+		opt.Country, err = cmd.Flags().GetString("country")
+		cobra.CheckErr(err)
+		opt.Language, err = cmd.Flags().GetString("language")
+		cobra.CheckErr(err)
+		opt.Service, err = cmd.Flags().GetString("service")
+		cobra.CheckErr(err)
+		opt.SortBy, err = cmd.Flags().GetString("sort")
+		cobra.CheckErr(err)
+		opt.MinRating, err = cmd.Flags().GetFloat64("min-rating")
+		cobra.CheckErr(err)
+		opt.LastPage, err = cmd.Flags().GetInt("pages")
+		cobra.CheckErr(err)
+		cobra.CheckErr(opt.Validate())
+
+		ctx := cmd.Context()
+		filmC := make(chan *letterboxd.Film)
+		done := make(chan error)
+		count := 0
+		go client.Film.StreamBrowseWithChan(ctx, opt, filmC, done)
+		for {
+			select {
+			case film := <-filmC:
+				d, err := yaml.Marshal([]*letterboxd.Film{film})
+				cobra.CheckErr(err)
+				fmt.Println(string(d))
+				count++
+			case err := <-done:
+				if err != nil {
+					log.WithError(err).Fatal("Error streaming browse")
+				}
+				log.WithFields(log.Fields{
+					"count": count,
+				}).Info("Films")
+				return
+			}
+		}
+	},
+}
+
+func init() {
+	scrapeCmd.AddCommand(browseCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	browseCmd.Flags().StringArray("genre", []string{}, "Films must be in this genre. May be given more than once")
+	browseCmd.Flags().StringArray("exclude-genre", []string{}, "Films must not be in this genre. May be given more than once")
+	browseCmd.Flags().Int("decade", 0, "Only films from this decade, like 1980")
+	browseCmd.Flags().Int("year", 0, "Only films from this year")
+	browseCmd.Flags().String("country", "", "Only films from this country, like 'usa'")
+	browseCmd.Flags().String("language", "", "Only films in this language, like 'english'")
+	browseCmd.Flags().String("service", "", "Only films on this streaming service, like 'netflix-us'")
+	browseCmd.Flags().String("sort", "", fmt.Sprintf("Sort order, one of %v", letterboxd.BrowseSortOrders()))
+	browseCmd.Flags().Float64("min-rating", 0, "Only films with at least this average rating")
+	browseCmd.Flags().Int("pages", 1, "Pages of films to fetch. Use -1 for all of them")
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2022 Drew Stinnett <drew@drewlink.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drewstinnett/letterrestd/analysis"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// compareCmd represents the compare command
+var compareCmd = &cobra.Command{
+	Use:   "compare USER USER...",
+	Short: "Compare the watched films and watchlists of two or more users",
+	Long: `Shows the films every user has watched, the films on one user's watchlist
+that others have watched, and how compatible each pair's taste is.`,
+	Args: cobra.RangeArgs(2, analysis.MaxCompareUsers),
+	Run: func(cmd *cobra.Command, args []string) {
+		minShared, err := cmd.Flags().GetInt("min-shared-ratings")
+		cobra.CheckErr(err)
+		comparison, err := analysis.CompareUsers(cmd.Context(), client, args, &analysis.CompareOpt{
+			MinSharedRatings: minShared,
+		})
+		cobra.CheckErr(err)
+		d, err := yaml.Marshal(comparison)
+		cobra.CheckErr(err)
+		fmt.Println(string(d))
+	},
+}
+
+func init() {
+	scrapeCmd.AddCommand(compareCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	compareCmd.Flags().Int("min-shared-ratings", analysis.DefaultMinSharedRatings, "Films a pair must have both rated to get a compatibility score")
+}
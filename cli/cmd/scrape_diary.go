@@ -0,0 +1,83 @@
+/*
+Copyright © 2022 Drew Stinnett <drew@drewlink.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// diaryCmd represents the diary command
+var diaryCmd = &cobra.Command{
+	Use:   "diary USERNAME",
+	Short: "Stream a users film diary",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		year, err := cmd.Flags().GetInt("year")
+		cobra.CheckErr(err)
+		month, err := cmd.Flags().GetInt("month")
+		cobra.CheckErr(err)
+		opt := &letterboxd.DiaryOpt{
+			Year:  year,
+			Month: month,
+		}
+		cobra.CheckErr(opt.Validate())
+
+		ctx := cmd.Context()
+		entryC := make(chan *letterboxd.DiaryEntry)
+		done := make(chan error)
+		count := 0
+		go client.User.StreamDiaryWithChan(ctx, args[0], opt, entryC, done)
+		for {
+			select {
+			case entry := <-entryC:
+				d, err := yaml.Marshal([]*letterboxd.DiaryEntry{entry})
+				cobra.CheckErr(err)
+				fmt.Println(string(d))
+				count++
+			case err := <-done:
+				if err != nil {
+					log.WithError(err).Fatal("Error streaming diary")
+				}
+				log.WithFields(log.Fields{
+					"count": count,
+				}).Info("Diary entries")
+				return
+			}
+		}
+	},
+}
+
+func init() {
+	scrapeCmd.AddCommand(diaryCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	diaryCmd.Flags().Int("year", 0, "Only show entries from this year")
+	diaryCmd.Flags().Int("month", 0, "Only show entries from this month. Requires --year")
+}
@@ -22,7 +22,6 @@ THE SOFTWARE.
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/apex/log"
@@ -37,7 +36,7 @@ var listCmd = &cobra.Command{
 	Short: "Get information about a given list",
 	Args:  cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		filmC := make(chan *letterboxd.Film)
 		doneC := make(chan error)
 		go client.User.StreamListWithChan(ctx, args[0], args[1], filmC, doneC)
@@ -52,12 +51,11 @@ var listCmd = &cobra.Command{
 				fmt.Println(string(d))
 			case err := <-doneC:
 				if err != nil {
-					log.WithError(err).Error("Error streaming watched")
+					log.WithError(err).Fatal("Error streaming watched")
 				} else {
 					log.Info("Finished")
 					return
 				}
-			default:
 			}
 		}
 	},
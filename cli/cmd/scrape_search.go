@@ -0,0 +1,62 @@
+/*
+Copyright © 2022 Drew Stinnett <drew@drewlink.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search QUERY",
+	Short: "Search for films by title",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		year, err := cmd.Flags().GetInt("year")
+		cobra.CheckErr(err)
+		pages, err := cmd.Flags().GetInt("pages")
+		cobra.CheckErr(err)
+		results, _, err := client.Film.Search(cmd.Context(), args[0], &letterboxd.SearchOpt{
+			Year:     year,
+			LastPage: pages,
+		})
+		cobra.CheckErr(err)
+		d, err := yaml.Marshal(results)
+		cobra.CheckErr(err)
+		fmt.Println(string(d))
+	},
+}
+
+func init() {
+	scrapeCmd.AddCommand(searchCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	searchCmd.Flags().Int("year", 0, "Only show films released this year")
+	searchCmd.Flags().Int("pages", 1, "Pages of results to fetch. Use -1 for all of them")
+}
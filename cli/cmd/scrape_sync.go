@@ -0,0 +1,65 @@
+/*
+Copyright © 2022 Drew Stinnett <drew@drewlink.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync USER...",
+	Short: "Show the films users have watched, or unwatched, since the last sync",
+	Long: `Compares each user's watched films with the state saved by the last sync,
+only walking as many pages as it needs to. The new state is saved for the next
+run.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := cmd.Flags().GetString("state-dir")
+		cobra.CheckErr(err)
+		store, err := letterboxd.NewFileSyncStateStore(dir)
+		cobra.CheckErr(err)
+		deltas := map[string]*letterboxd.WatchedDelta{}
+		for _, user := range args {
+			delta, err := letterboxd.SyncWatchedWithStore(cmd.Context(), client.User, store, user)
+			cobra.CheckErr(err)
+			deltas[user] = delta
+		}
+		d, err := yaml.Marshal(deltas)
+		cobra.CheckErr(err)
+		fmt.Println(string(d))
+	},
+}
+
+func init() {
+	scrapeCmd.AddCommand(syncCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	syncCmd.Flags().String("state-dir", "sync-state", "Directory to keep each user's sync state in")
+}
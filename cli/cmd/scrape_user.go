@@ -34,7 +34,7 @@ var userCmd = &cobra.Command{
 	Short: "Show user information",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		profile, _, err := client.User.Profile(ctx, args[0])
+		profile, _, err := client.User.Profile(cmd.Context(), args[0])
 		cobra.CheckErr(err)
 		d, err := yaml.Marshal(profile)
 		cobra.CheckErr(err)
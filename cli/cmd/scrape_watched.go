@@ -22,7 +22,6 @@ THE SOFTWARE.
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/apex/log"
@@ -43,7 +42,7 @@ var watchedCmd = &cobra.Command{
 		// cobra.CheckErr(err)
 		stream, err := cmd.Flags().GetBool("stream")
 		cobra.CheckErr(err)
-		ctx := context.Background()
+		ctx := cmd.Context()
 		if stream {
 			log.Info("Streaming movies")
 			watched := make(chan *letterboxd.Film, 0)
@@ -60,12 +59,11 @@ var watchedCmd = &cobra.Command{
 					fmt.Println(string(d))
 				case err := <-done:
 					if err != nil {
-						log.WithError(err).Error("Error streaming watched")
+						log.WithError(err).Fatal("Error streaming watched")
 					} else {
 						log.Info("Finished")
 						return
 					}
-				default:
 				}
 			}
 
@@ -80,10 +78,14 @@ var watchedCmd = &cobra.Command{
 			var showfilms []*letterboxd.Film
 			for i := 1; i <= pagination.TotalPages; i++ {
 				bar.Add(1)
-				filmset := <-watched
+				filmset, ok := <-watched
+				if !ok {
+					break
+				}
 				showfilms = append(showfilms, filmset...)
 				count += len(filmset)
 			}
+			cobra.CheckErr(ctx.Err())
 			d, err := yaml.Marshal(showfilms)
 			cobra.CheckErr(err)
 			fmt.Println(string(d))
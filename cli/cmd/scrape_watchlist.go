@@ -22,7 +22,6 @@ THE SOFTWARE.
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/apex/log"
@@ -36,7 +35,7 @@ var watchlistCmd = &cobra.Command{
 	Short: "Show a users watchlist",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		items, _, err := client.User.WatchList(ctx, args[0])
 		cobra.CheckErr(err)
 		d, err := yaml.Marshal(items)
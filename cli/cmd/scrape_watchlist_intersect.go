@@ -0,0 +1,75 @@
+/*
+Copyright © 2022 Drew Stinnett <drew@drewlink.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drewstinnett/letterrestd/analysis"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// watchlistIntersectCmd represents the watchlist-intersect command
+var watchlistIntersectCmd = &cobra.Command{
+	Use:   "watchlist-intersect USER USER...",
+	Short: "Films on every user's watchlist, best rated first",
+	Long: `Finds the films on every user's watchlist, or on at least --min-users of
+them, ranked by average rating. Good for picking a film to watch together.`,
+	Args: cobra.RangeArgs(2, analysis.MaxCompareUsers),
+	Run: func(cmd *cobra.Command, args []string) {
+		opt := &analysis.IntersectOpt{}
+		var err error
+		opt.MinUsers, err = cmd.Flags().GetInt("min-users")
+		cobra.CheckErr(err)
+		opt.MinRuntime, err = cmd.Flags().GetInt("min-runtime")
+		cobra.CheckErr(err)
+		opt.MaxRuntime, err = cmd.Flags().GetInt("max-runtime")
+		cobra.CheckErr(err)
+		opt.Genres, err = cmd.Flags().GetStringArray("genre")
+		cobra.CheckErr(err)
+		opt.ExcludeGenres, err = cmd.Flags().GetStringArray("exclude-genre")
+		cobra.CheckErr(err)
+		opt.ExcludeWatched, err = cmd.Flags().GetBool("exclude-watched")
+		cobra.CheckErr(err)
+		films, err := analysis.IntersectWatchlists(cmd.Context(), client, args, opt)
+		cobra.CheckErr(err)
+		d, err := yaml.Marshal(films)
+		cobra.CheckErr(err)
+		fmt.Println(string(d))
+	},
+}
+
+func init() {
+	scrapeCmd.AddCommand(watchlistIntersectCmd)
+
+	// Here you will define your flags and configuration settings.
+
+	// Cobra supports local flags which will only run when this command
+	// is called directly, e.g.:
+	watchlistIntersectCmd.Flags().Int("min-users", 0, "Watchlists a film must be on. Defaults to all of them")
+	watchlistIntersectCmd.Flags().Int("min-runtime", 0, "Shortest runtime, in minutes")
+	watchlistIntersectCmd.Flags().Int("max-runtime", 0, "Longest runtime, in minutes")
+	watchlistIntersectCmd.Flags().StringArray("genre", []string{}, "Films must be in this genre. May be given more than once")
+	watchlistIntersectCmd.Flags().StringArray("exclude-genre", []string{}, "Films must not be in this genre. May be given more than once")
+	watchlistIntersectCmd.Flags().Bool("exclude-watched", false, "Leave out films any of the users has already watched")
+}
@@ -22,6 +22,12 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/apex/log"
 	"github.com/drewstinnett/letterrestd/web"
 	"github.com/spf13/cobra"
 )
@@ -31,13 +37,64 @@ var serverCmd = &cobra.Command{
 	Use:   "server",
 	Short: "Run a RESTful server to interact with Letterboxd",
 	Run: func(cmd *cobra.Command, args []string) {
-		r := web.NewRouter(nil)
+		r := web.NewRouter(&web.RouterOpt{
+			ScrapeClient: client,
+		})
 		listen, err := cmd.Flags().GetString("listen")
 		cobra.CheckErr(err)
-		r.Run(listen)
+		watch, err := cmd.Flags().GetBool("watch")
+		cobra.CheckErr(err)
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+		watcherDone := make(chan struct{})
+		if watch {
+			w, err := newWatcher()
+			cobra.CheckErr(err)
+			go func() {
+				defer close(watcherDone)
+				if err := w.Run(ctx); err != nil {
+					log.WithError(err).Error("Watcher stopped")
+				}
+			}()
+		} else {
+			close(watcherDone)
+		}
+		err = serve(ctx, &http.Server{Addr: listen, Handler: r})
+		// Stop the watcher too if the server failed, and let it finish up
+		cancel()
+		<-watcherDone
+		cobra.CheckErr(err)
 	},
 }
 
+// shutdownTimeout is how long in flight requests get to finish once the
+// server is told to stop
+const shutdownTimeout = 10 * time.Second
+
+// serve runs srv until ctx is cancelled, then shuts it down gracefully
+func serve(ctx context.Context, srv *http.Server) error {
+	errC := make(chan error, 1)
+	go func() {
+		log.WithField("addr", srv.Addr).Info("Listening")
+		errC <- srv.ListenAndServe()
+	}()
+	select {
+	case err := <-errC:
+		return err
+	case <-ctx.Done():
+	}
+	log.Info("Shutting down server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errC; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(serverCmd)
 
@@ -49,5 +106,5 @@ func init() {
 
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
-	// serverCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	serverCmd.Flags().Bool("watch", false, "Also run the watcher, as configured in the 'watch' section of the config file")
 }
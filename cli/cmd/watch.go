@@ -0,0 +1,60 @@
+/*
+Copyright © 2022 Drew Stinnett <drew@drewlink.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"errors"
+
+	"github.com/drewstinnett/letterrestd/watcher"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Post watchlist, diary and list changes to webhooks",
+	Long: `Polls the users and lists in the 'watch' section of the config file, and
+posts each change to the configured webhooks. Runs until interrupted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		w, err := newWatcher()
+		cobra.CheckErr(err)
+		cobra.CheckErr(w.Run(cmd.Context()))
+	},
+}
+
+// newWatcher builds the watcher from the 'watch' section of the config file.
+// See the README for an example
+func newWatcher() (*watcher.Watcher, error) {
+	if !viper.IsSet("watch") {
+		return nil, errors.New("no 'watch' section in the config file")
+	}
+	config := &watcher.Config{}
+	if err := viper.UnmarshalKey("watch", config); err != nil {
+		return nil, err
+	}
+	return watcher.New(client, nil, config)
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+}
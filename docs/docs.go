@@ -16,9 +16,414 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/collections/{slug}": {
+            "get": {
+                "description": "Get the films in a collection, like the Halloween films, in release order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Get a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection slug, like 'halloween-collection'",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/compare": {
+            "get": {
+                "description": "Films every user has watched, films on one user's watchlist that others have watched, and a taste compatibility score for each pair of users. Every user's full watched history is scraped, so this can be slow",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Compare the films of two or more users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated usernames, like 'alice,bob'",
+                        "name": "users",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Films a pair must have both rated to get a compatibility score. Defaults to 5",
+                        "name": "min_shared_ratings",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/films": {
+            "get": {
+                "description": "Get a page of films matching a set of filters, like letterboxd's own browse pages",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Browse films",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Films must be in every one of these genres, like 'horror'",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Films must not be in any of these genres",
+                        "name": "exclude_genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Decade, like 1980. Can't be used with year",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Release year. Can't be used with decade",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country, like 'usa'",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language, like 'english'",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Streaming service, like 'netflix-us'",
+                        "name": "service",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order, like 'rating' or 'release-earliest'",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only films with at least this average rating",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page of films to fetch. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/imdb/{id}": {
+            "get": {
+                "description": "Get a film from its IMDb ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Get a film by IMDb ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDb ID, like 'tt0084787'",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/tmdb/{id}": {
+            "get": {
+                "description": "Get a film from its TMDb movie ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Get a film by TMDb ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "TMDb movie ID, like '1091'",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/films/{slug}": {
             "get": {
-                "description": "Get a film from a film slug",
+                "description": "Get a film from a film slug",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Get List Example",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Film slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/{slug}/credits": {
+            "get": {
+                "description": "Get the cast and crew for a film from a film slug",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Get film credits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Film slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/films/{slug}/reviews": {
+            "get": {
+                "description": "Get a page of the reviews of a film",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "films"
+                ],
+                "summary": "Get reviews per film",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Film slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page of reviews to fetch. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/catalog": {
+            "get": {
+                "description": "Get the curated lists this server knows about, sorted in to groups. Any group can be used with the batch scrape",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "list"
+                ],
+                "summary": "Get the curated list catalog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return this group",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{user}/{slug}": {
+            "get": {
+                "description": "Get a user's list, with its metadata and every entry in order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "list"
+                ],
+                "summary": "Get List Example",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username of the list owner",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "List slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the note for each entry. This fetches the slower detail view",
+                        "name": "notes",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Search for films by title. Results are ranked, best match first",
                 "consumes": [
                     "application/json"
                 ],
@@ -28,14 +433,70 @@ const docTemplate = `{
                 "tags": [
                     "films"
                 ],
-                "summary": "Get List Example",
+                "summary": "Search for films",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Film slug",
-                        "name": "slug",
+                        "description": "Title to search for",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return films released this year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page of results to fetch. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/diary": {
+            "get": {
+                "description": "Get the diary of a user, newest first, optionally for a single year or month",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get diary entries per user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user",
+                        "name": "user",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only entries from this year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only entries from this month. Requires year",
+                        "name": "month",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -48,9 +509,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/lists/{user}/{slug}": {
+        "/users/{user}/followers": {
             "get": {
-                "description": "Get a list of films from a user's list",
+                "description": "Get the users that follow a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -58,23 +519,194 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "list"
+                    "users"
                 ],
-                "summary": "Get List Example",
+                "summary": "Get who follows a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Username of the list owner",
+                        "description": "user",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/following": {
+            "get": {
+                "description": "Get the users that a user follows",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get who a user follows",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/graph": {
+            "get": {
+                "description": "Walk out from a user through who they follow, a number of hops deep. Graphs stop growing at 500 users, and are marked as truncated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the social graph around a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user",
                         "name": "user",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "Hops out from the user. Defaults to 2",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Walk followers instead of who each user follows",
+                        "name": "followers",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/lists": {
+            "get": {
+                "description": "Get the metadata for every list a user has made",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "list"
+                ],
+                "summary": "Get lists per user",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "List slug",
-                        "name": "slug",
+                        "description": "user",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/ratings": {
+            "get": {
+                "description": "Get every film a user has rated, along with a histogram of their ratings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get ratings per user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/reviews": {
+            "get": {
+                "description": "Get a page of the reviews written by a user, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get reviews per user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user",
+                        "name": "user",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page of reviews to fetch. Defaults to 1",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -118,6 +750,82 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/watchlists/intersection": {
+            "get": {
+                "description": "Films on every named user's watchlist, or on at least min_users of them, best average rating first. Handy for picking a film for movie night",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Films on several users' watchlists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated usernames, like 'alice,bob,carol'",
+                        "name": "users",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Watchlists a film must be on. Defaults to all of them",
+                        "name": "min_users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Shortest runtime, in minutes",
+                        "name": "min_runtime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Longest runtime, in minutes",
+                        "name": "max_runtime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Films must be in every one of these genres, like 'horror'",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Films must not be in any of these genres",
+                        "name": "exclude_genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Leave out films any of the users has already watched. This scrapes every user's watched films, so is slow",
+                        "name": "exclude_watched",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.APIResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -133,6 +841,9 @@ const docTemplate = `{
                 "next_page": {
                     "type": "integer"
                 },
+                "total_items": {
+                    "type": "integer"
+                },
                 "total_pages": {
                     "type": "integer"
                 }
@@ -0,0 +1,163 @@
+package letterboxd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apex/log"
+)
+
+var browseSlugRegex = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// BrowseOpt filters the films browsed from letterboxd's '/films/' pages.
+// Filters are slugs as used in letterboxd URLs, like 'science-fiction' or
+// 'netflix-us'
+type BrowseOpt struct {
+	Genres        []string // Films must be in every one of these genres
+	ExcludeGenres []string // Films must not be in any of these genres
+	Decade        int      // Like 1980. Can't be used with Year
+	Year          int      // Can't be used with Decade
+	Country       string   // Like 'usa' or 'japan'
+	Language      string   // Like 'english'
+	Service       string   // Streaming service, like 'netflix-us'
+	SortBy        string   // One of BrowseSortOrders(). Defaults to letterboxd's own order, which is by popularity
+	MinRating     float64  // Drop films with a lower average rating. Checked after the films are fetched, so pages may come back short
+	FirstPage     int      // First page to fetch. Defaults to 1
+	LastPage      int      // Last page to fetch. Defaults to FirstPage. Use -1 to fetch all pages
+}
+
+// BrowseSortOrders returns the sort orders letterboxd offers when browsing
+func BrowseSortOrders() []string {
+	return []string{"popular", "rating", "rating-lowest", "release", "release-earliest", "name", "shortest", "longest"}
+}
+
+func (b *BrowseOpt) Validate() error {
+	if b.Decade != 0 && b.Year != 0 {
+		return errors.New("Decade and Year can't be used together")
+	}
+	if b.Decade < 0 || b.Decade%10 != 0 {
+		return errors.New("Decade must be the first year of a decade, like 1980")
+	}
+	if b.Year < 0 {
+		return errors.New("Year must be positive")
+	}
+	if b.MinRating < 0 || b.MinRating > 5 {
+		return errors.New("MinRating must be between 0 and 5")
+	}
+	if b.SortBy != "" && !StringInSlice(b.SortBy, BrowseSortOrders()) {
+		return fmt.Errorf("SortBy must be one of %v", BrowseSortOrders())
+	}
+	if err := validatePages(b.FirstPage, b.LastPage); err != nil {
+		return err
+	}
+	slugs := append(append([]string{}, b.Genres...), b.ExcludeGenres...)
+	for _, slug := range append(slugs, b.Country, b.Language, b.Service) {
+		if slug != "" && !browseSlugRegex.MatchString(slug) {
+			return fmt.Errorf("invalid filter: %q", slug)
+		}
+	}
+	return nil
+}
+
+// path returns the browse path for the filters, like
+// '/films/genre/horror+-comedy/decade/1980s/by/rating/'
+func (b *BrowseOpt) path() string {
+	var p strings.Builder
+	p.WriteString("/films/")
+	if len(b.Genres) > 0 || len(b.ExcludeGenres) > 0 {
+		genres := append([]string{}, b.Genres...)
+		for _, genre := range b.ExcludeGenres {
+			genres = append(genres, "-"+genre)
+		}
+		fmt.Fprintf(&p, "genre/%s/", strings.Join(genres, "+"))
+	}
+	switch {
+	case b.Decade != 0:
+		fmt.Fprintf(&p, "decade/%ds/", b.Decade)
+	case b.Year != 0:
+		fmt.Fprintf(&p, "year/%d/", b.Year)
+	}
+	if b.Country != "" {
+		fmt.Fprintf(&p, "country/%s/", b.Country)
+	}
+	if b.Language != "" {
+		fmt.Fprintf(&p, "language/%s/", b.Language)
+	}
+	if b.Service != "" {
+		fmt.Fprintf(&p, "on/%s/", b.Service)
+	}
+	if b.SortBy != "" {
+		fmt.Fprintf(&p, "by/%s/", b.SortBy)
+	}
+	return p.String()
+}
+
+// Browse returns the films matching the filters from the requested pages. The
+// Pagination is for the last page fetched
+func (f *FilmServiceOp) Browse(ctx context.Context, opt *BrowseOpt) ([]*Film, *Pagination, error) {
+	films := []*Film{}
+	pagination, err := f.browsePages(ctx, opt, func(page []*Film) error {
+		films = append(films, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return films, pagination, nil
+}
+
+// StreamBrowseWithChan sends the films matching the filters down rchan, a page
+// at a time. done receives exactly one value once all films are sent
+func (f *FilmServiceOp) StreamBrowseWithChan(ctx context.Context, opt *BrowseOpt, rchan chan *Film, done chan error) {
+	var err error
+	defer func() {
+		log.Debug("Closing StreamBrowseWithChan")
+		done <- err
+	}()
+	_, err = f.browsePages(ctx, opt, func(page []*Film) error {
+		return sendFilms(ctx, rchan, page)
+	})
+}
+
+// browsePages walks the browse pages in order, handing each page of enhanced
+// films that pass the MinRating filter to fn
+func (f *FilmServiceOp) browsePages(ctx context.Context, opt *BrowseOpt, fn func([]*Film) error) (*Pagination, error) {
+	if opt == nil {
+		opt = &BrowseOpt{}
+	}
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	startPage, stopPage, err := normalizeStartStop(opt.FirstPage, opt.LastPage)
+	if err != nil {
+		return nil, err
+	}
+	for page := startPage; ; page++ {
+		films, pagination, err := f.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s%spage/%d/", f.client.BaseURL, opt.path(), page))
+		if err != nil {
+			return nil, err
+		}
+		if opt.MinRating > 0 {
+			kept := []*Film{}
+			for _, film := range films {
+				if film.AverageRating >= opt.MinRating {
+					kept = append(kept, film)
+				}
+			}
+			films = kept
+		}
+		if err = fn(films); err != nil {
+			return nil, err
+		}
+		if pagination.IsLast || (stopPage >= 0 && page >= stopPage) {
+			return pagination, nil
+		}
+		if page-startPage+1 >= maxPages {
+			log.WithField("path", opt.path()).Warn("Stopping browse at the page limit")
+			return pagination, nil
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package letterboxd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrowseOptPath(t *testing.T) {
+	tests := []struct {
+		opt  BrowseOpt
+		want string
+	}{
+		{BrowseOpt{}, "/films/"},
+		{BrowseOpt{Genres: []string{"horror"}, Decade: 1980, SortBy: "rating"}, "/films/genre/horror/decade/1980s/by/rating/"},
+		{BrowseOpt{Genres: []string{"horror", "comedy"}, ExcludeGenres: []string{"animation"}}, "/films/genre/horror+comedy+-animation/"},
+		{BrowseOpt{ExcludeGenres: []string{"documentary"}, Year: 2019}, "/films/genre/-documentary/year/2019/"},
+		{BrowseOpt{Country: "japan", Language: "japanese", Service: "netflix-us"}, "/films/country/japan/language/japanese/on/netflix-us/"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, tt.opt.path())
+	}
+}
+
+func TestBrowseOptValidate(t *testing.T) {
+	require.NoError(t, (&BrowseOpt{Genres: []string{"science-fiction"}, Decade: 1990, MinRating: 3.5}).Validate())
+	require.NoError(t, (&BrowseOpt{FirstPage: 2, LastPage: -1}).Validate())
+	bad := []*BrowseOpt{
+		{Decade: 1980, Year: 1985},
+		{Decade: 1985},
+		{MinRating: 6},
+		{SortBy: "vibes"},
+		{Genres: []string{"horror/../"}},
+		{Service: "Netflix US"},
+		{FirstPage: -1},
+		{FirstPage: 3, LastPage: 2},
+		{LastPage: -2},
+	}
+	for _, opt := range bad {
+		require.Error(t, opt.Validate(), fmt.Sprintf("%+v", opt))
+	}
+}
+
+func newBrowseTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fixture string
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/films/genre/horror/decade/1980s/by/rating/page/"):
+			fixture = fmt.Sprintf("testdata/browse/%v.html", strings.Split(r.URL.Path, "/")[9])
+		case strings.HasPrefix(r.URL.Path, "/film/"):
+			fixture = "testdata/film/sweetback.html"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open(fixture)
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+}
+
+func TestBrowse(t *testing.T) {
+	srv := newBrowseTestServer(t)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	opt := &BrowseOpt{Genres: []string{"horror"}, Decade: 1980, SortBy: "rating"}
+	films, pagination, err := client.Film.Browse(context.Background(), opt)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(films))
+	require.Equal(t, "the-thing", films[0].Slug)
+	require.False(t, pagination.IsLast)
+
+	opt.LastPage = -1
+	films, pagination, err = client.Film.Browse(context.Background(), opt)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(films))
+	require.True(t, pagination.IsLast)
+	require.NotNil(t, films[4].ExternalIDs)
+
+	// Every film in the fixtures gets the sweetback average of 3.21
+	opt.MinRating = 3.5
+	films, _, err = client.Film.Browse(context.Background(), opt)
+	require.NoError(t, err)
+	require.Empty(t, films)
+}
+
+func TestStreamBrowseWithChan(t *testing.T) {
+	srv := newBrowseTestServer(t)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	filmC := make(chan *Film)
+	done := make(chan error)
+	go client.Film.StreamBrowseWithChan(context.Background(), &BrowseOpt{
+		Genres:   []string{"horror"},
+		Decade:   1980,
+		SortBy:   "rating",
+		LastPage: -1,
+	}, filmC, done)
+	var slugs []string
+	for {
+		select {
+		case film := <-filmC:
+			slugs = append(slugs, film.Slug)
+		case err := <-done:
+			require.NoError(t, err)
+			require.Equal(t, []string{"the-thing", "the-shining", "an-american-werewolf-in-london", "the-fly-1986", "evil-dead-ii"}, slugs)
+			return
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package letterboxd
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apex/log"
+)
+
+// DefaultMemoryCacheSize is the number of pages kept by NewMemoryCache when
+// no size is given
+const DefaultMemoryCacheSize = 1000
+
+// Cache stores raw page bodies, keyed by URL
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// CacheTTLs controls how long each kind of page stays in the cache
+type CacheTTLs struct {
+	Film    time.Duration // Film pages, including /themes
+	User    time.Duration // Watched films, watchlists, reviews and the like
+	List    time.Duration // User lists
+	Default time.Duration // Everything else
+}
+
+// DefaultCacheTTLs film pages rarely change, but user activity does
+func DefaultCacheTTLs() CacheTTLs {
+	return CacheTTLs{
+		Film:    72 * time.Hour,
+		User:    5 * time.Minute,
+		List:    time.Hour,
+		Default: time.Hour,
+	}
+}
+
+// filmActivityPages are the pages under a film that show what members are
+// doing with it. They change as often as a user's pages do
+var filmActivityPages = map[string]bool{
+	"reviews": true,
+	"ratings": true,
+	"members": true,
+	"fans":    true,
+	"likes":   true,
+	"lists":   true,
+}
+
+// ForURL returns the TTL that should be used when caching the given URL
+func (t CacheTTLs) ForURL(u *url.URL) time.Duration {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case parts[0] == "film" && len(parts) >= 3 && filmActivityPages[parts[2]]:
+		return t.User
+	case parts[0] == "film":
+		return t.Film
+	case len(parts) >= 2 && (parts[1] == "films" || parts[1] == "watchlist"):
+		return t.User
+	case len(parts) >= 2 && parts[1] == "list":
+		return t.List
+	default:
+		return t.Default
+	}
+}
+
+// MemoryCache is an in-memory LRU cache
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache returns an LRU cache holding at most maxEntries pages. Use 0
+// for DefaultMemoryCacheSize
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryCacheSize
+	}
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      map[string]*list.Element{},
+	}
+}
+
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expires) {
+		m.ll.Remove(el)
+		delete(m.items, key)
+		return nil, false
+	}
+	m.ll.MoveToFront(el)
+	return entry.value, true
+}
+
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expires := time.Now().Add(ttl)
+	if el, ok := m.items[key]; ok {
+		entry := el.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expires = expires
+		m.ll.MoveToFront(el)
+		return
+	}
+	m.items[key] = m.ll.PushFront(&memoryCacheEntry{key: key, value: value, expires: expires})
+	for m.ll.Len() > m.maxEntries {
+		oldest := m.ll.Back()
+		m.ll.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// FileCache stores each page as a file in a directory
+type FileCache struct {
+	dir string
+}
+
+type fileCacheEntry struct {
+	Expires time.Time `json:"expires"`
+	Body    []byte    `json:"body"`
+}
+
+// NewFileCache returns a cache backed by dir, creating it if needed
+func NewFileCache(dir string) (*FileCache, error) {
+	if dir == "" {
+		return nil, errors.New("cache directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:]))
+}
+
+func (f *FileCache) Get(key string) ([]byte, bool) {
+	b, err := os.ReadFile(f.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry fileCacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		log.WithError(err).WithField("key", key).Debug("Ignoring corrupt cache entry")
+		return nil, false
+	}
+	if time.Now().After(entry.Expires) {
+		os.Remove(f.path(key))
+		return nil, false
+	}
+	return entry.Body, true
+}
+
+func (f *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	b, err := json.Marshal(fileCacheEntry{
+		Expires: time.Now().Add(ttl),
+		Body:    value,
+	})
+	if err != nil {
+		log.WithError(err).Warn("Failed to encode cache entry")
+		return
+	}
+	// Write to a temp file first so readers never see a partial entry
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		log.WithError(err).Warn("Failed to write cache entry")
+		return
+	}
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), f.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		log.WithError(err).Warn("Failed to write cache entry")
+	}
+}
@@ -0,0 +1,120 @@
+package letterboxd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", []byte("1"), time.Minute)
+	c.Set("b", []byte("2"), time.Minute)
+
+	// Touch a so that b becomes the least recently used
+	got, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), got)
+
+	c.Set("c", []byte("3"), time.Minute)
+	_, ok = c.Get("b")
+	require.False(t, ok)
+	_, ok = c.Get("a")
+	require.True(t, ok)
+	_, ok = c.Get("c")
+	require.True(t, ok)
+}
+
+func TestMemoryCacheExpires(t *testing.T) {
+	c := NewMemoryCache(0)
+	c.Set("a", []byte("1"), -time.Second)
+	_, ok := c.Get("a")
+	require.False(t, ok)
+}
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewFileCache(dir)
+	require.NoError(t, err)
+
+	_, ok := c.Get("https://letterboxd.com/film/the-thing/")
+	require.False(t, ok)
+
+	c.Set("https://letterboxd.com/film/the-thing/", []byte("thing"), time.Minute)
+	got, ok := c.Get("https://letterboxd.com/film/the-thing/")
+	require.True(t, ok)
+	require.Equal(t, []byte("thing"), got)
+
+	// A fresh cache on the same directory should see the same entries
+	c2, err := NewFileCache(dir)
+	require.NoError(t, err)
+	_, ok = c2.Get("https://letterboxd.com/film/the-thing/")
+	require.True(t, ok)
+
+	c.Set("expired", []byte("old"), -time.Second)
+	_, ok = c.Get("expired")
+	require.False(t, ok)
+
+	_, err = NewFileCache("")
+	require.Error(t, err)
+}
+
+func TestCacheTTLsForURL(t *testing.T) {
+	ttls := DefaultCacheTTLs()
+	tests := []struct {
+		url  string
+		want time.Duration
+	}{
+		{"https://letterboxd.com/film/sweet-sweetbacks-baadasssss-song/", ttls.Film},
+		{"https://letterboxd.com/film/sweet-sweetbacks-baadasssss-song/themes", ttls.Film},
+		{"https://letterboxd.com/film/sweet-sweetbacks-baadasssss-song/reviews/page/2/", ttls.User},
+		{"https://letterboxd.com/film/sweet-sweetbacks-baadasssss-song/reviews/by/activity/", ttls.User},
+		{"https://letterboxd.com/someguy/films/page/2/", ttls.User},
+		{"https://letterboxd.com/someguy/watchlist/page/1", ttls.User},
+		{"https://letterboxd.com/dave/list/imdb-top-250/page/1", ttls.List},
+		{"https://letterboxd.com/actor/nicolas-cage", ttls.Default},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, ttls.ForURL(u), tt.url)
+	}
+}
+
+func TestScrapeClientCache(t *testing.T) {
+	var hits int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/film/") {
+			atomic.AddInt64(&hits, 1)
+			rp, err := os.Open("testdata/film/sweetback.html")
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client := NewScrapeClient(nil, &ClientOptions{
+		Cache: NewMemoryCache(0),
+	})
+	client.BaseURL = srv.URL
+
+	for i := 0; i < 3; i++ {
+		film, err := client.Film.Get(context.Background(), "sweet-sweetbacks-baadasssss-song")
+		require.NoError(t, err)
+		require.Equal(t, "Sweet Sweetback's Baadasssss Song", film.Title)
+	}
+	require.Equal(t, int64(1), atomic.LoadInt64(&hits))
+}
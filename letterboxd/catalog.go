@@ -0,0 +1,208 @@
+package letterboxd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	listUserRegex = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+	listSlugRegex = regexp.MustCompile(`^[a-z0-9-]+$`)
+)
+
+// ListCatalog is a set of curated lists, sorted in to named groups like
+// 'horror' or 'canon'. A list can be in more than one group
+type ListCatalog struct {
+	Groups []*ListGroup `json:"groups" yaml:"groups"`
+}
+
+// ListGroup is a named group of lists in a ListCatalog
+type ListGroup struct {
+	Name        string    `json:"name" yaml:"name"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Lists       []*ListID `json:"lists" yaml:"lists"`
+}
+
+// Validate makes sure the list points somewhere letterboxd could have a list
+func (l *ListID) Validate() error {
+	if !listUserRegex.MatchString(l.User) {
+		return fmt.Errorf("invalid list user: %q", l.User)
+	}
+	if !listSlugRegex.MatchString(l.Slug) {
+		return fmt.Errorf("invalid list slug for %v: %q", l.User, l.Slug)
+	}
+	return nil
+}
+
+func (l *ListID) String() string {
+	return fmt.Sprintf("%s/%s", l.User, l.Slug)
+}
+
+// Validate checks every group has a unique name, and that every list in it is
+// valid and only listed once
+func (c *ListCatalog) Validate() error {
+	groups := map[string]bool{}
+	for _, group := range c.Groups {
+		if group.Name == "" {
+			return errors.New("list group is missing a name")
+		}
+		if groups[group.Name] {
+			return fmt.Errorf("duplicate list group: %v", group.Name)
+		}
+		groups[group.Name] = true
+		if len(group.Lists) == 0 {
+			return fmt.Errorf("list group %v has no lists", group.Name)
+		}
+		lists := map[string]bool{}
+		for _, list := range group.Lists {
+			if err := list.Validate(); err != nil {
+				return fmt.Errorf("list group %v: %w", group.Name, err)
+			}
+			if lists[list.String()] {
+				return fmt.Errorf("list group %v: duplicate list %v", group.Name, list)
+			}
+			lists[list.String()] = true
+		}
+	}
+	return nil
+}
+
+// Group returns the group with the given name
+func (c *ListCatalog) Group(name string) (*ListGroup, error) {
+	for _, group := range c.Groups {
+		if group.Name == name {
+			return group, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: list group %q", ErrNotFound, name)
+}
+
+// GroupNames returns the name of each group, in catalog order
+func (c *ListCatalog) GroupNames() []string {
+	names := make([]string, 0, len(c.Groups))
+	for _, group := range c.Groups {
+		names = append(names, group.Name)
+	}
+	return names
+}
+
+// Lists returns every list in the named groups, or in every group if none are
+// named. Lists in more than one group are only returned once
+func (c *ListCatalog) Lists(groups ...string) ([]*ListID, error) {
+	selected := c.Groups
+	if len(groups) > 0 {
+		selected = make([]*ListGroup, 0, len(groups))
+		for _, name := range groups {
+			group, err := c.Group(name)
+			if err != nil {
+				return nil, err
+			}
+			selected = append(selected, group)
+		}
+	}
+	seen := map[string]bool{}
+	lists := []*ListID{}
+	for _, group := range selected {
+		for _, list := range group.Lists {
+			if seen[list.String()] {
+				continue
+			}
+			seen[list.String()] = true
+			lists = append(lists, list)
+		}
+	}
+	return lists, nil
+}
+
+// ParseListCatalog reads a catalog from YAML or JSON, and validates it
+func ParseListCatalog(b []byte) (*ListCatalog, error) {
+	c := &ListCatalog{}
+	// JSON is valid YAML, so this handles both
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LoadListCatalog reads a catalog from a YAML or JSON file
+func LoadListCatalog(path string) (*ListCatalog, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c, err := ParseListCatalog(b)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return c, nil
+}
+
+// DefaultListCatalog is the catalog used when a client isn't given one. These
+// are the well known lists that track the canon, or a genre
+func DefaultListCatalog() *ListCatalog {
+	return &ListCatalog{
+		Groups: []*ListGroup{
+			{
+				Name:        "canon",
+				Description: "The lists people mean when they talk about the greatest films",
+				Lists: []*ListID{
+					{User: "dave", Slug: "official-top-250-narrative-feature-films"},
+					{User: "dave", Slug: "imdb-top-250"},
+					{User: "gubarenko", Slug: "1001-movies-you-must-see-before-you-die-2021"},
+					{User: "liveandrew", Slug: "bfi-2012-critics-top-250-films"},
+					{User: "moseschan", Slug: "afi-100-years-100-movies"},
+					{User: "crew", Slug: "edgar-wrights-1000-favorite-movies"},
+				},
+			},
+			{
+				Name:        "horror",
+				Description: "Horror films",
+				Lists: []*ListID{
+					{User: "darrencb", Slug: "letterboxds-top-250-horror-films"},
+				},
+			},
+			{
+				Name:        "documentary",
+				Description: "Documentary films",
+				Lists: []*ListID{
+					{User: "jack", Slug: "official-top-250-documentary-films"},
+				},
+			},
+			{
+				Name:        "animation",
+				Description: "Animated films",
+				Lists: []*ListID{
+					{User: "lifeasfiction", Slug: "letterboxd-100-animation"},
+				},
+			},
+			{
+				Name:        "women-directors",
+				Description: "Films directed by women",
+				Lists: []*ListID{
+					{User: "jack", Slug: "women-directors-the-official-top-250-narrative"},
+				},
+			},
+			{
+				Name:        "awards",
+				Description: "Award winners",
+				Lists: []*ListID{
+					{User: "jake_ziegler", Slug: "academy-award-winners-for-best-picture"},
+				},
+			},
+			{
+				Name:        "box-office",
+				Description: "The biggest earners",
+				Lists: []*ListID{
+					{User: "matthew", Slug: "box-office-mojo-all-time-worldwide"},
+				},
+			},
+		},
+	}
+}
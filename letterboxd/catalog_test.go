@@ -0,0 +1,80 @@
+package letterboxd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultListCatalog(t *testing.T) {
+	c := DefaultListCatalog()
+	require.NoError(t, c.Validate())
+	require.Contains(t, c.GroupNames(), "horror")
+	require.Contains(t, c.GroupNames(), "canon")
+
+	client := NewScrapeClient(nil, nil)
+	require.Equal(t, c, client.List.Catalog(context.Background()))
+}
+
+func TestLoadListCatalog(t *testing.T) {
+	c, err := LoadListCatalog("testdata/catalog/catalog.yaml")
+	require.NoError(t, err)
+	require.Equal(t, []string{"horror", "canon"}, c.GroupNames())
+	horror, err := c.Group("horror")
+	require.NoError(t, err)
+	require.Equal(t, "Scary movies", horror.Description)
+	require.Equal(t, &ListID{User: "jack", Slug: "halloween"}, horror.Lists[1])
+
+	c, err = LoadListCatalog("testdata/catalog/catalog.json")
+	require.NoError(t, err)
+	require.Equal(t, []string{"canon"}, c.GroupNames())
+
+	_, err = LoadListCatalog("testdata/catalog/missing.yaml")
+	require.Error(t, err)
+}
+
+func TestParseListCatalogInvalid(t *testing.T) {
+	tests := map[string]string{
+		"trailing slash": "groups:\n- name: animation\n  lists:\n  - {user: lifeasfiction, slug: letterboxd-100-animation/}\n",
+		"missing user":   "groups:\n- name: canon\n  lists:\n  - {slug: imdb-top-250}\n",
+		"missing name":   "groups:\n- lists:\n  - {user: dave, slug: imdb-top-250}\n",
+		"no lists":       "groups:\n- name: canon\n",
+		"duplicate group": "groups:\n- name: canon\n  lists:\n  - {user: dave, slug: imdb-top-250}\n" +
+			"- name: canon\n  lists:\n  - {user: dave, slug: imdb-top-250}\n",
+		"duplicate list": "groups:\n- name: canon\n  lists:\n  - {user: dave, slug: imdb-top-250}\n  - {user: dave, slug: imdb-top-250}\n",
+		"unknown field":  "groups:\n- name: canon\n  films: []\n",
+	}
+	for desc, catalog := range tests {
+		_, err := ParseListCatalog([]byte(catalog))
+		require.Error(t, err, desc)
+	}
+}
+
+func TestListCatalogLists(t *testing.T) {
+	c, err := LoadListCatalog("testdata/catalog/catalog.yaml")
+	require.NoError(t, err)
+
+	// Lists in more than one group only show up once
+	all, err := c.Lists()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(all))
+
+	canon, err := c.Lists("canon")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(canon))
+
+	_, err = c.Lists("westerns")
+	require.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestStreamBatchUnknownListGroup(t *testing.T) {
+	client := NewScrapeClient(nil, nil)
+	filmC := make(chan *Film)
+	done := make(chan error)
+	go client.Film.StreamBatchWithChan(context.Background(), &FilmBatchOpts{
+		ListGroups: []string{"westerns"},
+	}, filmC, done)
+	require.True(t, errors.Is(<-done, ErrNotFound))
+}
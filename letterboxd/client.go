@@ -2,9 +2,8 @@ package letterboxd
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
-	"fmt"
 	"io"
 	"net/http"
 	"time"
@@ -16,17 +15,29 @@ import (
 const (
 	baseURL  = "https://letterboxd.com"
 	maxPages = 50
+
+	// DefaultRate is the requests per second a client makes, unless told
+	// otherwise. Be nice to letterboxd.com
+	DefaultRate        = 5
+	DefaultBurst       = 10
+	DefaultConcurrency = 5
 )
 
 type ScrapeClient struct {
-	client    *http.Client
-	UserAgent string
+	client          *http.Client
+	cache           Cache
+	cacheTTLs       CacheTTLs
+	catalog         *ListCatalog
+	filmStore       FilmStore
+	filmStoreMaxAge time.Duration
+	UserAgent       string
 	// Config    ClientConfig
 	BaseURL string
 	User    UserService
 	Film    FilmService
 	List    ListService
 	URL     URLService
+	Review  ReviewService
 	// Location  LocationService
 	// Volume    VolumeService
 }
@@ -35,16 +46,84 @@ type Response struct {
 	*http.Response
 }
 
+// ClientOptions tunes the behavior of a ScrapeClient. A nil *ClientOptions
+// uses the defaults
+type ClientOptions struct {
+	Cache     Cache         // Cache for fetched pages. Nil disables caching
+	CacheTTLs *CacheTTLs    // How long each kind of page is cached. Defaults to DefaultCacheTTLs()
+	Retry     *RetryOptions // How failed requests are retried. Nil uses the RetryOptions defaults
+	Catalog   *ListCatalog  // Curated lists to offer. Nil uses DefaultListCatalog()
+
+	FilmStore       FilmStore     // Keeps enhanced films between runs. Nil fetches every film each time
+	FilmStoreMaxAge time.Duration // How long a stored film is used before it is fetched again. 0 uses DefaultFilmStoreMaxAge
+
+	Rate        float64            // Requests per second across all hosts. 0 uses DefaultRate, negative disables the limit
+	Burst       int                // Requests allowed in a burst. 0 uses DefaultBurst
+	Concurrency int                // Requests in flight at once. 0 uses DefaultConcurrency, negative disables the limit
+	HostRates   map[string]float64 // Requests per second for specific hosts, on top of Rate
+}
+
 // NewClient Generic new client creation
-func NewScrapeClient(httpClient *http.Client) *ScrapeClient {
+func NewScrapeClient(httpClient *http.Client, opts *ClientOptions) *ScrapeClient {
+	if opts == nil {
+		opts = &ClientOptions{}
+	}
 	if httpClient == nil {
 		httpClient = http.DefaultClient
-		// allows 60 requests every 10 seconds
-		// httpClient.Transport = NewThrottledTransport(1*time.Second, 60, http.DefaultTransport)
 	}
+	// Work on a copy, so we don't change the transport of a client that
+	// someone else might be using, like http.DefaultClient
+	hc := *httpClient
+	httpClient = &hc
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	burst := opts.Burst
+	if burst <= 0 {
+		burst = DefaultBurst
+	}
+	if len(opts.HostRates) > 0 {
+		transport = NewHostThrottledTransport(opts.HostRates, burst, transport)
+	}
+	switch {
+	case opts.Rate == 0:
+		transport = newRateThrottledTransport(DefaultRate, burst, transport)
+	case opts.Rate > 0:
+		transport = newRateThrottledTransport(opts.Rate, burst, transport)
+	}
+	switch {
+	case opts.Concurrency == 0:
+		transport = NewConcurrencyTransport(DefaultConcurrency, transport)
+	case opts.Concurrency > 0:
+		transport = NewConcurrencyTransport(opts.Concurrency, transport)
+	}
+	retry := RetryOptions{}
+	if opts.Retry != nil {
+		retry = *opts.Retry
+	}
+	httpClient.Transport = NewRetryTransport(retry, transport)
 
 	userAgent := "letterrestd"
-	c := &ScrapeClient{client: httpClient, UserAgent: userAgent, BaseURL: baseURL}
+	c := &ScrapeClient{
+		client:          httpClient,
+		cache:           opts.Cache,
+		cacheTTLs:       DefaultCacheTTLs(),
+		catalog:         opts.Catalog,
+		filmStore:       opts.FilmStore,
+		filmStoreMaxAge: opts.FilmStoreMaxAge,
+		UserAgent:       userAgent,
+		BaseURL:         baseURL,
+	}
+	if opts.CacheTTLs != nil {
+		c.cacheTTLs = *opts.CacheTTLs
+	}
+	if c.filmStoreMaxAge <= 0 {
+		c.filmStoreMaxAge = DefaultFilmStoreMaxAge
+	}
+	if c.catalog == nil {
+		c.catalog = DefaultListCatalog()
+	}
 
 	// c.Location = &LocationServiceOp{client: c}
 	// c.Volume = &VolumeServiceOp{client: c}
@@ -52,6 +131,7 @@ func NewScrapeClient(httpClient *http.Client) *ScrapeClient {
 	c.Film = &FilmServiceOp{client: c}
 	c.List = &ListServiceOp{client: c}
 	c.URL = &URLServiceOp{client: c}
+	c.Review = &ReviewServiceOp{client: c}
 	return c
 }
 
@@ -85,58 +165,113 @@ func NewThrottledTransport(limitPeriod time.Duration, requestCount int, transpor
 	}
 }
 
-func (c *ScrapeClient) getBody(url string) ([]byte, error) {
-	req, err := http.NewRequest("GET", url, nil)
+// cachedBody returns the cached page for a GET request, if there is one
+func (c *ScrapeClient) cachedBody(req *http.Request) ([]byte, bool) {
+	if c.cache == nil || req.Method != http.MethodGet {
+		return nil, false
+	}
+	b, ok := c.cache.Get(req.URL.String())
+	if ok {
+		log.WithField("url", req.URL.String()).Debug("Cache hit")
+	}
+	return b, ok
+}
+
+// cacheBody stores a successfully fetched page, using the TTL for its page type
+func (c *ScrapeClient) cacheBody(req *http.Request, b []byte) {
+	if c.cache == nil || req.Method != http.MethodGet {
+		return
+	}
+	c.cache.Set(req.URL.String(), b, c.cacheTTLs.ForURL(req.URL))
+}
+
+// newRateThrottledTransport is NewThrottledTransport for a rate given in
+// requests per second
+func newRateThrottledTransport(perSecond float64, burst int, transportWrap http.RoundTripper) http.RoundTripper {
+	return &ThrottledTransport{
+		roundTripperWrap: transportWrap,
+		ratelimiter:      rate.NewLimiter(rate.Limit(perSecond), burst),
+	}
+}
+
+func (c *ScrapeClient) getBody(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
+	if b, ok := c.cachedBody(req); ok {
+		return b, nil
+	}
 	res, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
+	if err := statusError(req, res); err != nil {
+		return nil, err
+	}
 	b, err := io.ReadAll(res.Body)
 	if err != nil {
 		return nil, err
 	}
+	c.cacheBody(req, b)
 	return b, nil
 }
 
+// statusError returns the error for a response letterboxd didn't answer
+// successfully, or nil if it did
+func statusError(req *http.Request, res *http.Response) error {
+	if res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	err := errorFromResponse(res)
+	log.WithFields(log.Fields{
+		"status": res.StatusCode,
+		"url":    req.URL.String(),
+	}).WithError(err).Warn("Unexpected status")
+	return err
+}
+
 func (c *ScrapeClient) sendRequest(req *http.Request, extractor func(io.Reader) (interface{}, *Pagination, error)) (*PageData, *Response, error) {
+	if b, ok := c.cachedBody(req); ok {
+		return extractPage(b, &Response{&http.Response{StatusCode: http.StatusOK, Request: req}}, extractor)
+	}
 	res, err := c.client.Do(req)
 	req.Close = true
 	if err != nil {
+		// Cancellation is the caller's doing, not letterboxd's
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
 		log.WithError(err).Warn("Error sending request")
-		return nil, nil, err
+		return nil, nil, &UpstreamError{URL: req.URL.String(), Err: err}
 	}
-	// b, _ := ioutil.ReadAll(res.Body)
 
 	defer res.Body.Close()
 
-	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
-		var errRes ErrorResponse
-		// b, _ := ioutil.ReadAll(res.Body)
-		// log.Debug(string(b))
-		if err = json.NewDecoder(res.Body).Decode(&errRes); err == nil {
-			return nil, nil, errors.New(errRes.Message)
-		}
-
-		if res.StatusCode == http.StatusTooManyRequests {
-			return nil, nil, fmt.Errorf("too many requests.  Check rate limit and make sure the userAgent is set right")
-		} else if res.StatusCode == http.StatusNotFound {
-			log.WithFields(log.Fields{
-				"status": res.StatusCode,
-				"url":    req.URL.String(),
-			}).Warn("Not found")
-			return nil, nil, fmt.Errorf("that entry was not found, are you sure it exists?")
-		} else {
-			return nil, nil, fmt.Errorf("error, status code: %d", res.StatusCode)
-		}
+	if err = statusError(req, res); err != nil {
+		return nil, nil, err
 	}
 
 	b, err := io.ReadAll(res.Body)
 	if err != nil {
 		return nil, nil, err
 	}
+	d, r, err := extractPage(b, &Response{res}, extractor)
+	if err != nil {
+		var perr *ParseError
+		if errors.As(err, &perr) && perr.URL == "" {
+			perr.URL = req.URL.String()
+		}
+		return nil, nil, err
+	}
+	// Only cache pages we know how to read
+	c.cacheBody(req, b)
+	return d, r, nil
+}
+
+// extractPage runs extractor against a fetched page body
+func extractPage(b []byte, r *Response, extractor func(io.Reader) (interface{}, *Pagination, error)) (*PageData, *Response, error) {
 	items, pagination, err := extractor(bytes.NewReader(b))
 	if err != nil {
 		log.Warn("Error parsing response")
@@ -149,7 +284,6 @@ func (c *ScrapeClient) sendRequest(req *http.Request, extractor func(io.Reader)
 			return nil, nil, err
 		}
 	*/
-	r := &Response{res}
 	d := &PageData{
 		Data: items,
 	}
@@ -159,7 +293,3 @@ func (c *ScrapeClient) sendRequest(req *http.Request, extractor func(io.Reader)
 
 	return d, r, nil
 }
-
-type ErrorResponse struct {
-	Message string `json:"errors"`
-}
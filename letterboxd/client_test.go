@@ -0,0 +1,80 @@
+package letterboxd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client for a test server, without the politeness
+// limits meant for letterboxd.com
+func newTestClient(baseURL string) *ScrapeClient {
+	client := NewScrapeClient(nil, &ClientOptions{
+		Rate:        -1,
+		Concurrency: -1,
+	})
+	client.BaseURL = baseURL
+	return client
+}
+
+func TestScrapeClientRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := NewScrapeClient(nil, &ClientOptions{
+		Rate:  20,
+		Burst: 1,
+	})
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err := client.getBody(context.Background(), srv.URL)
+		require.NoError(t, err)
+	}
+	// 1 request from the burst, then 4 more at 50ms intervals
+	require.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestHostThrottledTransport(t *testing.T) {
+	tr := NewHostThrottledTransport(map[string]float64{"letterboxd.com": 1}, 1, http.DefaultTransport).(*HostThrottledTransport)
+	require.NotNil(t, tr.limiterFor("letterboxd.com"))
+	require.NotNil(t, tr.limiterFor("www.Letterboxd.com"))
+	require.Nil(t, tr.limiterFor("example.com"))
+	require.Nil(t, tr.limiterFor("127.0.0.1"))
+}
+
+func TestConcurrencyTransport(t *testing.T) {
+	var inFlight, maxInFlight int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		for {
+			m := atomic.LoadInt64(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt64(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := NewScrapeClient(nil, &ClientOptions{
+		Rate:        -1,
+		Concurrency: 2,
+	})
+	client.BaseURL = srv.URL
+	films := []*Film{}
+	for i := 0; i < 10; i++ {
+		films = append(films, &Film{Target: "/film/whatever/"})
+	}
+	err := client.Film.EnhanceFilmList(context.Background(), &films)
+	require.NoError(t, err)
+	require.LessOrEqual(t, atomic.LoadInt64(&maxInFlight), int64(2))
+}
@@ -0,0 +1,81 @@
+package letterboxd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/apex/log"
+)
+
+// FilmCollection is a franchise or series of films, like
+// '/films/in/halloween-collection/'
+type FilmCollection struct {
+	Slug  string  `json:"slug"`
+	Name  string  `json:"name"`
+	Films []*Film `json:"films"` // In release order, earliest first
+}
+
+// Collection returns the films in a collection, in release order. The films
+// are enhanced the same way as EnhanceFilmList
+func (f *FilmServiceOp) Collection(ctx context.Context, slug string) (*FilmCollection, error) {
+	var collection *FilmCollection
+	for page := 1; page <= maxPages; page++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/films/in/%s/by/release-earliest/page/%d/", f.client.BaseURL, slug, page), nil)
+		if err != nil {
+			return nil, err
+		}
+		items, _, err := f.client.sendRequest(req, extractCollection)
+		if err != nil {
+			return nil, err
+		}
+		partial := items.Data.(*FilmCollection)
+		if collection == nil {
+			collection = partial
+		} else {
+			collection.Films = append(collection.Films, partial.Films...)
+		}
+		if items.Pagintion.IsLast {
+			break
+		}
+	}
+	collection.Slug = slug
+	if err := f.EnhanceFilmList(ctx, &collection.Films); err != nil {
+		log.WithError(err).Warn("Failed to enhance film list")
+		return nil, err
+	}
+	return collection, nil
+}
+
+func extractCollection(r io.Reader) (interface{}, *Pagination, error) {
+	var pageBuf bytes.Buffer
+	tee := io.TeeReader(r, &pageBuf)
+	doc, err := goquery.NewDocumentFromReader(tee)
+	if err != nil {
+		return nil, nil, err
+	}
+	collection := &FilmCollection{
+		Name:  strings.TrimSpace(doc.Find("h1.title-1").First().Text()),
+		Films: []*Film{},
+	}
+	if collection.Name == "" {
+		collection.Name = doc.Find("meta[property='og:title']").AttrOr("content", "")
+	}
+	if collection.Name == "" {
+		return nil, nil, &ParseError{Selector: "h1.title-1"}
+	}
+	doc.Find("li.poster-container div.film-poster").Each(func(i int, s *goquery.Selection) {
+		collection.Films = append(collection.Films, &Film{
+			ID:     s.AttrOr("data-film-id", ""),
+			Slug:   normalizeSlug(s.AttrOr("data-film-slug", "")),
+			Target: s.AttrOr("data-target-link", ""),
+			// Real film name appears in the alt attribute for the poster
+			Title: s.Find("img.image").AttrOr("alt", ""),
+		})
+	})
+	return collection, paginationOrSinglePage(&pageBuf), nil
+}
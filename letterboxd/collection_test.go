@@ -0,0 +1,79 @@
+package letterboxd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newCollectionTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fixture string
+		switch {
+		case r.URL.Path == "/films/in/halloween-collection/by/release-earliest/page/1/":
+			fixture = "testdata/collection/halloween-collection.html"
+		case strings.HasPrefix(r.URL.Path, "/film/"):
+			fixture = "testdata/film/sweetback.html"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open(fixture)
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+}
+
+func TestExtractCollection(t *testing.T) {
+	f, err := os.Open("testdata/collection/halloween-collection.html")
+	require.NoError(t, err)
+	defer f.Close()
+	items, pagination, err := extractCollection(f)
+	require.NoError(t, err)
+	require.True(t, pagination.IsLast)
+	collection := items.(*FilmCollection)
+	require.Equal(t, "Halloween Collection", collection.Name)
+	require.Equal(t, 4, len(collection.Films))
+	require.Equal(t, &Film{
+		ID:     "51577",
+		Slug:   "halloween-1978",
+		Target: "/film/halloween-1978/",
+		Title:  "Halloween",
+	}, collection.Films[0])
+	require.Equal(t, "halloween-2018", collection.Films[3].Slug)
+}
+
+func TestCollection(t *testing.T) {
+	srv := newCollectionTestServer(t)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	collection, err := client.Film.Collection(context.Background(), "halloween-collection")
+	require.NoError(t, err)
+	require.Equal(t, "halloween-collection", collection.Slug)
+	require.Equal(t, "Halloween Collection", collection.Name)
+	require.Equal(t, 4, len(collection.Films))
+	require.NotNil(t, collection.Films[0].ExternalIDs)
+
+	_, err = client.Film.Collection(context.Background(), "never-collection")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestURLCollection(t *testing.T) {
+	srv := newCollectionTestServer(t)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	items, err := client.URL.Items(context.Background(), "https://letterboxd.com/films/in/halloween-collection/")
+	require.NoError(t, err)
+	require.IsType(t, []*Film{}, items)
+	require.Equal(t, 4, len(items.([]*Film)))
+}
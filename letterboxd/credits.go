@@ -0,0 +1,99 @@
+package letterboxd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// FilmCredits is the full cast and crew of a film
+type FilmCredits struct {
+	Cast []*CastMember `json:"cast"`
+	Crew []*CrewMember `json:"crew"`
+}
+
+// CastMember is an actor in a film. Slug can be used as FilmographyOpt.Person
+// with the 'actor' profession
+type CastMember struct {
+	Name      string `json:"name"`
+	Character string `json:"character,omitempty"`
+	Slug      string `json:"slug"`
+}
+
+// CrewMember is someone who worked on a film behind the camera. Slug and
+// Profession can be used as FilmographyOpt.Person and FilmographyOpt.Profession
+type CrewMember struct {
+	Name       string `json:"name"`
+	Role       string `json:"role"`       // Role as shown on the film page, like 'Director' or 'Producers'
+	Profession string `json:"profession"` // Role as used in letterboxd URLs, like 'director' or 'producer'
+	Slug       string `json:"slug"`
+}
+
+// Credits returns the cast and crew for a film
+func (f *FilmServiceOp) Credits(ctx context.Context, slug string) (*FilmCredits, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/film/%s/", f.client.BaseURL, slug), nil)
+	if err != nil {
+		return nil, err
+	}
+	item, _, err := f.client.sendRequest(req, extractFilmCredits)
+	if err != nil {
+		return nil, err
+	}
+	return item.Data.(*FilmCredits), nil
+}
+
+func extractFilmCredits(r io.Reader) (interface{}, *Pagination, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	credits := &FilmCredits{
+		Cast: []*CastMember{},
+		Crew: []*CrewMember{},
+	}
+	doc.Find("div#tab-cast a.text-slug").Each(func(i int, s *goquery.Selection) {
+		profession, slug := splitPersonPath(s.AttrOr("href", ""))
+		if profession != "actor" {
+			return
+		}
+		credits.Cast = append(credits.Cast, &CastMember{
+			Name:      strings.TrimSpace(s.Text()),
+			Character: strings.TrimSpace(s.AttrOr("title", "")),
+			Slug:      slug,
+		})
+	})
+	doc.Find("div#tab-crew h3").Each(func(i int, s *goquery.Selection) {
+		role := strings.TrimSpace(s.Find("span").First().Text())
+		// The people for each role are in the block right after the heading
+		s.NextFiltered("div.text-sluglist").Find("a.text-slug").Each(func(i int, s *goquery.Selection) {
+			profession, slug := splitPersonPath(s.AttrOr("href", ""))
+			if slug == "" {
+				return
+			}
+			credits.Crew = append(credits.Crew, &CrewMember{
+				Name:       strings.TrimSpace(s.Text()),
+				Role:       role,
+				Profession: profession,
+				Slug:       slug,
+			})
+		})
+	})
+	if len(credits.Cast) == 0 && len(credits.Crew) == 0 && doc.Find("div#tab-cast, div#tab-crew").Length() == 0 {
+		return nil, nil, &ParseError{Selector: "div#tab-cast, div#tab-crew"}
+	}
+	return credits, nil, nil
+}
+
+// splitPersonPath splits a person link like '/actor/john-amos/' in to the
+// profession and the person slug
+func splitPersonPath(href string) (string, string) {
+	parts := strings.Split(strings.Trim(href, "/"), "/")
+	if len(parts) != 2 {
+		return "", ""
+	}
+	return parts[0], parts[1]
+}
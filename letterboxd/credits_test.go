@@ -0,0 +1,65 @@
+package letterboxd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractFilmCredits(t *testing.T) {
+	f, err := os.Open("testdata/film/sweetback.html")
+	defer f.Close()
+	require.NoError(t, err)
+	i, _, err := extractFilmCredits(f)
+	require.NoError(t, err)
+	credits := i.(*FilmCredits)
+
+	require.Greater(t, len(credits.Cast), 10)
+	require.Equal(t, &CastMember{Name: "Simon Chuckster", Character: "Beetle", Slug: "simon-chuckster"}, credits.Cast[0])
+	require.Equal(t, &CastMember{Name: "Melvin Van Peebles", Character: "Sweetback", Slug: "melvin-van-peebles"}, credits.Cast[1])
+
+	require.Contains(t, credits.Crew, &CrewMember{Name: "Melvin Van Peebles", Role: "Director", Profession: "director", Slug: "melvin-van-peebles"})
+	require.Contains(t, credits.Crew, &CrewMember{Name: "Jerry Gross", Role: "Producers", Profession: "producer", Slug: "jerry-gross"})
+	require.Contains(t, credits.Crew, &CrewMember{Name: "Robert Maxwell", Role: "Cinematography", Profession: "cinematography", Slug: "robert-maxwell"})
+
+	// Crew slugs should be usable for a filmography lookup
+	for _, c := range credits.Crew {
+		opt := &FilmographyOpt{Person: c.Slug, Profession: c.Profession}
+		require.NoError(t, opt.Validate(), c.Profession)
+	}
+	// Along with the crew roles this film doesn't have
+	for _, profession := range []string{"casting", "executive-producer", "sound", "visual-effects", "costume-design"} {
+		opt := &FilmographyOpt{Person: "someone", Profession: profession}
+		require.NoError(t, opt.Validate(), profession)
+	}
+}
+
+func TestFilmCredits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/film/sweet-sweetbacks-baadasssss-song") {
+			rp, err := os.Open("testdata/film/sweetback.html")
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	credits, err := client.Film.Credits(context.Background(), "sweet-sweetbacks-baadasssss-song")
+	require.NoError(t, err)
+	require.NotEmpty(t, credits.Cast)
+	require.NotEmpty(t, credits.Crew)
+
+	_, err = client.Film.Credits(context.Background(), "never-made")
+	require.ErrorIs(t, err, ErrNotFound)
+}
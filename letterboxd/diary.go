@@ -0,0 +1,187 @@
+package letterboxd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/apex/log"
+)
+
+// DiaryEntry is a single logged viewing from a user's diary
+type DiaryEntry struct {
+	Film        *Film     `json:"film"`
+	WatchedDate time.Time `json:"watched_date"`
+	Rating      float64   `json:"rating,omitempty"` // Stars, from 0.5 to 5. Zero if not rated
+	Rewatch     bool      `json:"rewatch"`
+	Liked       bool      `json:"liked"`
+	Tags        []string  `json:"tags,omitempty"`
+	ReviewLink  string    `json:"review_link,omitempty"` // Path to the review, like '/someguy/film/the-thing/'
+}
+
+// DiaryOpt filters the diary entries that are fetched
+type DiaryOpt struct {
+	Year  int // Only entries from this year
+	Month int // Only entries from this month. Requires Year
+}
+
+func (d *DiaryOpt) Validate() error {
+	if d.Month != 0 && d.Year == 0 {
+		return errors.New("Year is required when Month is set")
+	}
+	if d.Month < 0 || d.Month > 12 {
+		return errors.New("Month must be between 1 and 12")
+	}
+	if d.Year < 0 {
+		return errors.New("Year must be positive")
+	}
+	return nil
+}
+
+// path returns the diary path for the filter, like '/for/2022/04/'
+func (d *DiaryOpt) path() string {
+	switch {
+	case d.Month != 0:
+		return fmt.Sprintf("for/%d/%02d/", d.Year, d.Month)
+	case d.Year != 0:
+		return fmt.Sprintf("for/%d/", d.Year)
+	default:
+		return ""
+	}
+}
+
+// Diary returns all of the diary entries for a user, newest first
+func (u *UserServiceOp) Diary(ctx context.Context, userID string, opt *DiaryOpt) ([]*DiaryEntry, error) {
+	var entries []*DiaryEntry
+	err := u.diaryPages(ctx, userID, opt, func(page []*DiaryEntry) error {
+		entries = append(entries, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// StreamDiaryWithChan sends the diary entries for a user down rchan, newest
+// first. done receives exactly one value once all entries are sent
+func (u *UserServiceOp) StreamDiaryWithChan(ctx context.Context, userID string, opt *DiaryOpt, rchan chan *DiaryEntry, done chan error) {
+	var err error
+	defer func() {
+		log.Debug("Closing StreamDiaryWithChan")
+		done <- err
+	}()
+	err = u.diaryPages(ctx, userID, opt, func(page []*DiaryEntry) error {
+		for _, entry := range page {
+			select {
+			case rchan <- entry:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+}
+
+// diaryPages walks the diary pages in order, handing each page of entries to fn
+func (u *UserServiceOp) diaryPages(ctx context.Context, userID string, opt *DiaryOpt, fn func([]*DiaryEntry) error) error {
+	if opt == nil {
+		opt = &DiaryOpt{}
+	}
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+	page := 1
+	for {
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s/films/diary/%spage/%d/", u.client.BaseURL, userID, opt.path(), page), nil)
+		if err != nil {
+			return err
+		}
+		items, _, err := u.client.sendRequest(req, extractDiaryEntries)
+		if err != nil {
+			return err
+		}
+		if err = fn(items.Data.([]*DiaryEntry)); err != nil {
+			return err
+		}
+		if items.Pagintion.IsLast {
+			return nil
+		}
+		page++
+		if page > maxPages {
+			log.WithField("user", userID).Warn("Stopping diary at the page limit")
+			return nil
+		}
+	}
+}
+
+func extractDiaryEntries(r io.Reader) (interface{}, *Pagination, error) {
+	var pageBuf bytes.Buffer
+	tee := io.TeeReader(r, &pageBuf)
+	doc, err := goquery.NewDocumentFromReader(tee)
+	if err != nil {
+		return nil, nil, err
+	}
+	if doc.Find("table#diary-table").Length() == 0 {
+		return nil, nil, &ParseError{Selector: "table#diary-table"}
+	}
+	entries := []*DiaryEntry{}
+	doc.Find("tr.diary-entry-row").Each(func(i int, s *goquery.Selection) {
+		entry := &DiaryEntry{}
+		poster := s.Find("div.film-poster").First()
+		entry.Film = &Film{
+			ID:     poster.AttrOr("data-film-id", ""),
+			Slug:   normalizeSlug(poster.AttrOr("data-film-slug", "")),
+			Target: poster.AttrOr("data-target-link", ""),
+			Title:  poster.Find("img.image").AttrOr("alt", ""),
+		}
+		if year, err := strconv.Atoi(strings.TrimSpace(s.Find("td.td-released").Text())); err == nil {
+			entry.Film.Year = year
+		}
+
+		actions := s.Find("td.td-actions")
+		if d, err := time.Parse("2006-01-02", actions.AttrOr("data-viewing-date", "")); err == nil {
+			entry.WatchedDate = d
+		} else {
+			// Fall back on the day link, like '/someguy/films/diary/for/2022/04/18/'
+			entry.WatchedDate = diaryDateFromPath(s.Find("td.td-day a").AttrOr("href", ""))
+		}
+		if rating, err := strconv.Atoi(actions.AttrOr("data-rating", "")); err == nil {
+			entry.Rating = float64(rating) / 2
+		} else {
+			entry.Rating = starsFromRatedClass(s.Find("td.td-rating span.rating"))
+		}
+		if rewatch, ok := actions.Attr("data-rewatch"); ok {
+			entry.Rewatch = rewatch == "true"
+		} else if cell := s.Find("td.td-rewatch"); cell.Length() > 0 {
+			entry.Rewatch = !cell.HasClass("icon-status-off")
+		}
+		entry.Liked = s.Find("td.td-like span.icon-liked").Length() > 0
+		s.Find("ul.tags li a").Each(func(i int, s *goquery.Selection) {
+			entry.Tags = append(entry.Tags, strings.TrimSpace(s.Text()))
+		})
+		entry.ReviewLink = s.Find("td.td-review a").AttrOr("href", "")
+
+		entries = append(entries, entry)
+	})
+	return entries, paginationOrSinglePage(&pageBuf), nil
+}
+
+func diaryDateFromPath(p string) time.Time {
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	if len(parts) < 3 {
+		return time.Time{}
+	}
+	d, err := time.Parse("2006/01/02", strings.Join(parts[len(parts)-3:], "/"))
+	if err != nil {
+		return time.Time{}
+	}
+	return d
+}
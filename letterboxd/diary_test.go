@@ -0,0 +1,119 @@
+package letterboxd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractDiaryEntries(t *testing.T) {
+	f, err := os.Open("testdata/user/diary/1.html")
+	defer f.Close()
+	require.NoError(t, err)
+	items, pagination, err := extractDiaryEntries(f)
+	require.NoError(t, err)
+	require.Equal(t, 2, pagination.TotalPages)
+	entries := items.([]*DiaryEntry)
+	require.Equal(t, 2, len(entries))
+
+	first := entries[0]
+	require.Equal(t, "sweet-sweetbacks-baadasssss-song", first.Film.Slug)
+	require.Equal(t, "Sweet Sweetback's Baadasssss Song", first.Film.Title)
+	require.Equal(t, 1971, first.Film.Year)
+	require.Equal(t, time.Date(2022, 4, 18, 0, 0, 0, 0, time.UTC), first.WatchedDate)
+	require.Equal(t, 3.5, first.Rating)
+	require.False(t, first.Rewatch)
+	require.True(t, first.Liked)
+	require.Equal(t, []string{"blaxploitation", "criterion channel"}, first.Tags)
+	require.Equal(t, "/someguy/film/sweet-sweetbacks-baadasssss-song/", first.ReviewLink)
+
+	second := entries[1]
+	require.Equal(t, "the-thing", second.Film.Slug)
+	require.Equal(t, 5.0, second.Rating)
+	require.True(t, second.Rewatch)
+	require.Empty(t, second.ReviewLink)
+}
+
+func TestExtractDiaryEntriesWithoutRewatch(t *testing.T) {
+	f, err := os.Open("testdata/user/diary/no-rewatch.html")
+	defer f.Close()
+	require.NoError(t, err)
+	items, _, err := extractDiaryEntries(f)
+	require.NoError(t, err)
+	entries := items.([]*DiaryEntry)
+	require.Equal(t, 1, len(entries))
+	// Rows with no rewatch markup at all aren't rewatches
+	require.False(t, entries[0].Rewatch)
+}
+
+func TestDiaryOptValidate(t *testing.T) {
+	require.NoError(t, (&DiaryOpt{}).Validate())
+	require.NoError(t, (&DiaryOpt{Year: 2022, Month: 4}).Validate())
+	require.Error(t, (&DiaryOpt{Month: 4}).Validate())
+	require.Error(t, (&DiaryOpt{Year: 2022, Month: 13}).Validate())
+	require.Equal(t, "for/2022/04/", (&DiaryOpt{Year: 2022, Month: 4}).path())
+	require.Equal(t, "for/2022/", (&DiaryOpt{Year: 2022}).path())
+	require.Equal(t, "", (&DiaryOpt{}).path())
+}
+
+func newDiaryServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/someguy/films/diary/") {
+			parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+			rp, err := os.Open(fmt.Sprintf("testdata/user/diary/%v.html", parts[len(parts)-1]))
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+}
+
+func TestDiary(t *testing.T) {
+	srv := newDiaryServer(t)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	entries, err := client.User.Diary(context.Background(), "someguy", nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(entries))
+	require.Equal(t, "gremlins", entries[2].Film.Slug)
+	require.Equal(t, 0.0, entries[2].Rating)
+	require.False(t, entries[2].Liked)
+
+	_, err = client.User.Diary(context.Background(), "someguy", &DiaryOpt{Month: 3})
+	require.Error(t, err)
+}
+
+func TestStreamDiaryWithChan(t *testing.T) {
+	srv := newDiaryServer(t)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	entryC := make(chan *DiaryEntry)
+	done := make(chan error)
+	go client.User.StreamDiaryWithChan(context.Background(), "someguy", &DiaryOpt{}, entryC, done)
+	var entries []*DiaryEntry
+loop:
+	for {
+		select {
+		case entry := <-entryC:
+			entries = append(entries, entry)
+		case err := <-done:
+			require.NoError(t, err)
+			break loop
+		}
+	}
+	require.Equal(t, 3, len(entries))
+	require.Equal(t, "sweet-sweetbacks-baadasssss-song", entries[0].Film.Slug)
+}
@@ -0,0 +1,126 @@
+package letterboxd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Sentinel errors returned by the scrape client. Use errors.Is to check for
+// them, and errors.As to get at the details carried by the typed errors below
+var (
+	ErrNotFound       = errors.New("not found on letterboxd")
+	ErrPrivateProfile = errors.New("profile is private")
+	ErrRateLimited    = errors.New("rate limited by letterboxd")
+	ErrParse          = errors.New("could not parse letterboxd page")
+	ErrUpstream       = errors.New("letterboxd is unavailable")
+	ErrInvalidID      = errors.New("invalid external film ID")
+)
+
+// RateLimitError is returned when letterboxd answers with a 429
+type RateLimitError struct {
+	URL        string
+	RetryAfter time.Duration // Zero if letterboxd did not say
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%v, retry after %v", ErrRateLimited, e.RetryAfter)
+	}
+	return ErrRateLimited.Error()
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// ParseError is returned when a page was fetched, but did not contain what we
+// expected. Selector is the part of the page that could not be found
+type ParseError struct {
+	URL      string
+	Selector string
+	Err      error
+}
+
+func (e *ParseError) Error() string {
+	msg := fmt.Sprintf("%v: nothing found for %q", ErrParse, e.Selector)
+	if e.URL != "" {
+		msg = fmt.Sprintf("%v on %v", msg, e.URL)
+	}
+	if e.Err != nil {
+		msg = fmt.Sprintf("%v: %v", msg, e.Err)
+	}
+	return msg
+}
+
+func (e *ParseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// UpstreamError is returned when letterboxd can't be reached, or answers with
+// an unexpected status
+type UpstreamError struct {
+	URL        string
+	StatusCode int // Zero if no response was received
+	Err        error
+}
+
+func (e *UpstreamError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("%v: status code %d from %v", ErrUpstream, e.StatusCode, e.URL)
+	}
+	return fmt.Sprintf("%v: %v", ErrUpstream, e.Err)
+}
+
+func (e *UpstreamError) Is(target error) bool {
+	return target == ErrUpstream
+}
+
+func (e *UpstreamError) Unwrap() error {
+	return e.Err
+}
+
+// errorFromResponse converts a non-2xx response in to one of the errors above
+func errorFromResponse(res *http.Response) error {
+	u := res.Request.URL.String()
+	switch res.StatusCode {
+	case http.StatusNotFound, http.StatusGone:
+		return fmt.Errorf("%w: %v", ErrNotFound, u)
+	case http.StatusForbidden:
+		// Letterboxd answers with a 403 for content the member has hidden
+		return fmt.Errorf("%w: %v", ErrPrivateProfile, u)
+	case http.StatusTooManyRequests:
+		return &RateLimitError{
+			URL:        u,
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After")),
+		}
+	default:
+		return &UpstreamError{URL: u, StatusCode: res.StatusCode}
+	}
+}
+
+// parseRetryAfter understands both forms of the Retry-After header: a number
+// of seconds, or an HTTP date
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
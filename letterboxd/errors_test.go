@@ -0,0 +1,114 @@
+package letterboxd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"120", 2 * time.Minute},
+		{"-5", 0},
+		{"not a date", 0},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, parseRetryAfter(tt.value), tt.value)
+	}
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	require.Greater(t, parseRetryAfter(future), 58*time.Minute)
+}
+
+func TestSendRequestErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/missing"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/hidden"):
+			w.WriteHeader(http.StatusForbidden)
+		case strings.HasSuffix(r.URL.Path, "/busy"):
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case strings.HasSuffix(r.URL.Path, "/down"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte("<html><body>Nothing to see here</body></html>"))
+		}
+	}))
+	defer srv.Close()
+	// Retries would hide the individual responses we're testing here
+	client := NewScrapeClient(nil, &ClientOptions{
+		Retry: &RetryOptions{MaxAttempts: 1},
+	})
+	client.BaseURL = srv.URL
+
+	tests := []struct {
+		user string
+		want error
+	}{
+		{"missing", ErrNotFound},
+		{"hidden", ErrPrivateProfile},
+		{"busy", ErrRateLimited},
+		{"down", ErrUpstream},
+		{"garbage", ErrParse},
+	}
+	for _, tt := range tests {
+		_, _, err := client.User.Profile(context.Background(), tt.user)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, tt.want), "%v: got %v", tt.user, err)
+	}
+
+	_, _, err := client.User.Profile(context.Background(), "busy")
+	var rerr *RateLimitError
+	require.True(t, errors.As(err, &rerr))
+	require.Equal(t, 30*time.Second, rerr.RetryAfter)
+
+	_, _, err = client.User.Profile(context.Background(), "garbage")
+	var perr *ParseError
+	require.True(t, errors.As(err, &perr))
+	require.Equal(t, "section.js-profile-header[data-person]", perr.Selector)
+	require.Equal(t, srv.URL+"/garbage", perr.URL)
+}
+
+func TestFilmDetailsErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/film/never-made/"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasPrefix(r.URL.Path, "/film/busy/"):
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	client := NewScrapeClient(nil, &ClientOptions{
+		Retry: &RetryOptions{MaxAttempts: 1},
+	})
+	client.BaseURL = srv.URL
+	ctx := context.Background()
+
+	err := client.Film.GetFilmDetailsWithPreview(ctx, &Film{Slug: "never-made", Target: "/film/never-made/"})
+	require.True(t, errors.Is(err, ErrNotFound), err)
+
+	// Missing films are skipped, but a rate limit stops the whole list
+	films := []*Film{{Slug: "never-made", Target: "/film/never-made/"}}
+	require.NoError(t, client.Film.EnhanceFilmList(ctx, &films))
+	films = append(films, &Film{Slug: "busy", Target: "/film/busy/"})
+	err = client.Film.EnhanceFilmList(ctx, &films)
+	var rerr *RateLimitError
+	require.True(t, errors.As(err, &rerr), err)
+	require.Equal(t, 30*time.Second, rerr.RetryAfter)
+}
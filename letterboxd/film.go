@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -21,15 +23,31 @@ type ExternalFilmIDs struct {
 }
 
 type Film struct {
-	ID          string           `json:"id"`
-	Title       string           `json:"title"`
-	Slug        string           `json:"slug"`
-	Target      string           `json:"target"`
-	Genres      []string         `json:"genres,omitempty"`
-	Themes      []string         `json:"themes,omitempty"`
-	ExternalIDs *ExternalFilmIDs `json:"external_ids,omitempty"`
+	ID             string           `json:"id"`
+	Title          string           `json:"title"`
+	Slug           string           `json:"slug"`
+	Target         string           `json:"target"`
+	Year           int              `json:"year,omitempty"`
+	Directors      []string         `json:"directors,omitempty"`
+	Cast           []string         `json:"cast,omitempty"`
+	Countries      []string         `json:"countries,omitempty"`
+	Studios        []string         `json:"studios,omitempty"`
+	RuntimeMinutes int              `json:"runtime_minutes,omitempty"`
+	AverageRating  float64          `json:"average_rating,omitempty"`
+	RatingCount    int              `json:"rating_count,omitempty"`
+	PosterURL      string           `json:"poster_url,omitempty"`
+	Synopsis       string           `json:"synopsis,omitempty"`
+	UserRating     float64          `json:"user_rating,omitempty"` // Stars given by the member whose page the film came from
+	UserLiked      bool             `json:"user_liked,omitempty"`  // If the member whose page the film came from liked it
+	Genres         []string         `json:"genres,omitempty"`
+	Themes         []string         `json:"themes,omitempty"`
+	ExternalIDs    *ExternalFilmIDs `json:"external_ids,omitempty"`
+	Sources        []string         `json:"sources,omitempty"` // Where a batch found the film, like 'watched:someguy' or 'list:dave/imdb-top-250'
 }
 
+// TopBilledCastSize is how many actors, in billing order, are kept in Film.Cast
+const TopBilledCastSize = 10
+
 type FilmService interface {
 	// GetExternalIDs(context.Context, *Film) error
 	GetFilmDetailsWithPreview(context.Context, *Film) error
@@ -37,10 +55,19 @@ type FilmService interface {
 	EnhanceFilmList(context.Context, *[]*Film) error
 	Filmography(context.Context, *FilmographyOpt) ([]*Film, error)
 	Get(context.Context, string) (*Film, error)
+	GetByIMDB(context.Context, string) (*Film, error)
+	GetByTMDB(context.Context, string) (*Film, error)
+	Credits(context.Context, string) (*FilmCredits, error)
+	Collection(context.Context, string) (*FilmCollection, error)
+	Browse(context.Context, *BrowseOpt) ([]*Film, *Pagination, error)
+	StreamBrowseWithChan(context.Context, *BrowseOpt, chan *Film, chan error)
+	Search(context.Context, string, *SearchOpt) ([]*SearchResult, *Pagination, error)
 	ExtractFilmsWithPath(context.Context, string) ([]*Film, *Pagination, error)
 	ExtractEnhancedFilmsWithPath(context.Context, string) ([]*Film, *Pagination, error)
+	PreviewPages(context.Context, string) ([]*Film, error)
 	StreamBatch(context.Context, *FilmBatchOpts) (chan *Film, *Pagination, error)
 	StreamBatchWithChan(context.Context, *FilmBatchOpts, chan *Film, chan error)
+	EvalFilmSetExpr(context.Context, *FilmSetExpr) (*FilmSet, error)
 }
 
 type FilmServiceOp struct {
@@ -69,111 +96,74 @@ func (f *FilmographyOpt) Validate() error {
 }
 
 type FilmBatchOpts struct {
-	Watched   []string  `json:"watched"`
-	Lists     []*ListID `json:"lists"`
-	WatchList []string  `json:"watchlist"`
+	Watched    []string  `json:"watched"`
+	Lists      []*ListID `json:"lists"`
+	ListGroups []string  `json:"list_groups"` // Names of groups in the client's list catalog, to add to Lists
+	WatchList  []string  `json:"watchlist"`
+	// Expression combines sources with set operators instead, like
+	// 'list:dave/imdb-top-250 - watched:alice'. See FilmSetExpr. It can't be
+	// used with the other fields
+	Expression string `json:"expression"`
 }
 
-// StreamBatch Get a bunch of different films at once and stream them back to the user
+func (b *FilmBatchOpts) Validate() error {
+	if b.Expression != "" && (len(b.Watched) > 0 || len(b.Lists) > 0 || len(b.ListGroups) > 0 || len(b.WatchList) > 0) {
+		return errors.New("Expression can't be combined with Watched, Lists, ListGroups or WatchList")
+	}
+	return nil
+}
+
+// StreamBatchWithChan gets films from a bunch of different places at once and
+// streams them back to the user. The sources are unioned, so each film is sent
+// once, with every source it was found in
 func (f *FilmServiceOp) StreamBatchWithChan(ctx context.Context, batchOpts *FilmBatchOpts, filmsC chan *Film, done chan error) {
-	// var films []*Film
+	var err error
 	defer func() {
 		log.Info("Completed Stream Batch")
-		done <- nil
+		done <- err
 	}()
-	var wg sync.WaitGroup
-
-	// Handle User watched films first
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		for _, username := range batchOpts.Watched {
-			// userFilms := []Film{}
-			log.WithFields(log.Fields{
-				"username": username,
-			}).Info("Fetching watched films")
-			userFilmC := make(chan *Film)
-			userDone := make(chan error)
-			go f.client.User.StreamWatchedWithChan(ctx, username, userFilmC, userDone)
-			loop := true
-			for loop {
-				select {
-				case film := <-userFilmC:
-					filmsC <- film
-				case err := <-userDone:
-					if err != nil {
-						log.WithError(err).Error("Failed to get watched films")
-						done <- err
-					} else {
-						log.Info("Finished")
-						loop = false
-					}
-				}
-			}
-		}
-	}()
-	// Next up handle Lists
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		for _, listID := range batchOpts.Lists {
-			// userFilms := []Film{}
-			log.WithFields(log.Fields{
-				"username": listID.User,
-				"slug":     listID.Slug,
-			}).Info("Fetching list films")
-			listFilmC := make(chan *Film)
-			listDone := make(chan error)
-			go f.client.User.StreamListWithChan(ctx, listID.User, listID.Slug, listFilmC, listDone)
-			loop := true
-			for loop {
-				select {
-				case film := <-listFilmC:
-					filmsC <- film
-				case err := <-listDone:
-					if err != nil {
-						log.WithError(err).Error("Failed to get list films")
-						done <- err
-					} else {
-						log.Info("Finished")
-						loop = false
-					}
-				}
-			}
+	if err = batchOpts.Validate(); err != nil {
+		return
+	}
+	var expr *FilmSetExpr
+	if batchOpts.Expression != "" {
+		if expr, err = ParseFilmSetExpr(batchOpts.Expression); err != nil {
+			return
 		}
-	}()
+	} else if expr = batchOpts.unionExpr(); expr == nil {
+		return
+	}
+	err = f.streamFilmSetExpr(ctx, expr, filmsC)
+}
 
-	// Finally, handle watch lists
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		for _, user := range batchOpts.WatchList {
-			// userFilms := []Film{}
-			log.WithFields(log.Fields{
-				"username": user,
-			}).Info("Fetching watchlist films")
-			listFilmC := make(chan *Film)
-			listDone := make(chan error)
-			go f.client.User.StreamWatchListWithChan(ctx, user, listFilmC, listDone)
-			loop := true
-			for loop {
-				select {
-				case film := <-listFilmC:
-					filmsC <- film
-				case err := <-listDone:
-					if err != nil {
-						log.WithError(err).Error("Failed to get watchlist films")
-						done <- err
-					} else {
-						log.Info("Finished")
-						loop = false
-					}
-				}
-			}
-		}
-	}()
+// unionExpr returns the union of every source in the batch, or nil if there
+// aren't any
+func (b *FilmBatchOpts) unionExpr() *FilmSetExpr {
+	var sources []*filmSource
+	for _, user := range b.Watched {
+		sources = append(sources, &filmSource{kind: "watched", arg: user})
+	}
+	for _, list := range b.Lists {
+		sources = append(sources, &filmSource{kind: "list", arg: list.String()})
+	}
+	for _, group := range b.ListGroups {
+		sources = append(sources, &filmSource{kind: "group", arg: group})
+	}
+	for _, user := range b.WatchList {
+		sources = append(sources, &filmSource{kind: "watchlist", arg: user})
+	}
+	return unionFilmSetExpr(sources)
+}
 
-	wg.Wait()
+// streamFilmSetExpr works out the films in a film set expression, then sends
+// each of them once
+func (f *FilmServiceOp) streamFilmSetExpr(ctx context.Context, expr *FilmSetExpr, filmsC chan *Film) error {
+	log.WithField("expression", expr.String()).Info("Evaluating film set expression")
+	set, err := f.EvalFilmSetExpr(ctx, expr)
+	if err != nil {
+		return err
+	}
+	return sendFilms(ctx, filmsC, set.Films())
 }
 
 // StreamBatch Get a bunch of different films at once and stream them back to the user
@@ -211,7 +201,7 @@ func (f *FilmServiceOp) StreamBatch(ctx context.Context, batchOpts *FilmBatchOpt
 }
 
 func (f *FilmServiceOp) ExtractFilmsWithPath(ctx context.Context, path string) ([]*Film, *Pagination, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s", path), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -223,6 +213,25 @@ func (f *FilmServiceOp) ExtractFilmsWithPath(ctx context.Context, path string) (
 	return films, &firstItems.Pagintion, nil
 }
 
+// PreviewPages returns the films from every page of a film grid, like
+// 'alice/films' or 'dave/list/imdb-top-250', without looking up their
+// details. It stops at maxPages
+func (f *FilmServiceOp) PreviewPages(ctx context.Context, path string) ([]*Film, error) {
+	films := []*Film{}
+	for page := 1; page <= maxPages; page++ {
+		partial, pagination, err := f.ExtractFilmsWithPath(ctx, fmt.Sprintf("%s/%s/page/%d/", f.client.BaseURL, path, page))
+		if err != nil {
+			return nil, err
+		}
+		films = append(films, partial...)
+		if page >= pagination.TotalPages {
+			return films, nil
+		}
+	}
+	log.WithField("path", path).Warn("Stopping film grid at the page limit")
+	return films, nil
+}
+
 func (f *FilmServiceOp) ExtractEnhancedFilmsWithPath(ctx context.Context, path string) ([]*Film, *Pagination, error) {
 	films, pagination, err := f.ExtractFilmsWithPath(ctx, path)
 	if err != nil {
@@ -239,7 +248,42 @@ func (f *FilmServiceOp) ExtractEnhancedFilmsWithPath(ctx context.Context, path s
 }
 
 func (f *FilmServiceOp) Get(ctx context.Context, slug string) (*Film, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/film/%s", f.client.BaseURL, slug), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/film/%s", f.client.BaseURL, slug), nil)
+	if err != nil {
+		return nil, err
+	}
+	item, _, err := f.client.sendRequest(req, extractFilmFromFilmPage)
+	if err != nil {
+		return nil, err
+	}
+	return item.Data.(*Film), nil
+}
+
+var (
+	imdbIDRegex = regexp.MustCompile(`^tt\d+$`)
+	tmdbIDRegex = regexp.MustCompile(`^\d+$`)
+)
+
+// GetByIMDB returns the film for an IMDb ID, like 'tt0084787'
+func (f *FilmServiceOp) GetByIMDB(ctx context.Context, id string) (*Film, error) {
+	if !imdbIDRegex.MatchString(id) {
+		return nil, fmt.Errorf("%w: IMDb ID %q", ErrInvalidID, id)
+	}
+	return f.getByRedirect(ctx, fmt.Sprintf("%s/imdb/%s/", f.client.BaseURL, id))
+}
+
+// GetByTMDB returns the film for a TMDb movie ID, like '1091'
+func (f *FilmServiceOp) GetByTMDB(ctx context.Context, id string) (*Film, error) {
+	if !tmdbIDRegex.MatchString(id) {
+		return nil, fmt.Errorf("%w: TMDb ID %q", ErrInvalidID, id)
+	}
+	return f.getByRedirect(ctx, fmt.Sprintf("%s/tmdb/%s/", f.client.BaseURL, id))
+}
+
+// getByRedirect fetches a film through one of letterboxd's lookup URLs, which
+// redirect to the film page. Unknown IDs come back as ErrNotFound
+func (f *FilmServiceOp) getByRedirect(ctx context.Context, lookupURL string) (*Film, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", lookupURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -257,7 +301,7 @@ func (f *FilmServiceOp) Filmography(ctx context.Context, opt *FilmographyOpt) ([
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s/%s", f.client.BaseURL, opt.Profession, opt.Person), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s/%s", f.client.BaseURL, opt.Profession, opt.Person), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -285,47 +329,78 @@ func (f *FilmServiceOp) Filmography(ctx context.Context, opt *FilmographyOpt) ([
 	return films, nil
 }
 
+// EnhanceFilmList looks up the details of each film. Films that can't be
+// looked up are left as they are, but being rate limited is returned, since
+// the rest of the lookups would be too
 func (f *FilmServiceOp) EnhanceFilmList(ctx context.Context, films *[]*Film) error {
+	var limitOnce sync.Once
+	var limitErr error
 	var wg sync.WaitGroup
 	wg.Add(len(*films))
 	guard := make(chan struct{}, 5)
 	for _, film := range *films {
 		go func(film *Film) {
 			defer wg.Done()
-			guard <- struct{}{}
+			select {
+			case guard <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-guard }()
+			if f.client.storedFilmDetails(film) {
+				log.Debugf("Using stored %v", film.Slug)
+				return
+			}
 			log.Debugf("Looking up %v", film.Slug)
 			if err := f.GetFilmDetailsWithPreview(ctx, film); err != nil {
-				log.WithError(err).Warn("Failed to get external IDs")
-				// return err
+				if errors.Is(err, ErrRateLimited) {
+					limitOnce.Do(func() { limitErr = err })
+				}
+				if ctx.Err() == nil {
+					log.WithError(err).Warn("Failed to get external IDs")
+				}
+				return
+			}
+			if f.client.filmStore != nil {
+				if err := f.client.filmStore.PutFilm(film); err != nil {
+					log.WithError(err).WithField("slug", film.Slug).Warn("Failed to store film")
+				}
 			}
-			<-guard
 		}(film)
 	}
 	wg.Wait()
-	return nil
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return limitErr
 }
 
 func (f *FilmServiceOp) GetFilmDetailsWithPreview(ctx context.Context, film *Film) error {
-	b, err := f.client.getBody(fmt.Sprintf("%s%s", f.client.BaseURL, film.Target))
+	b, err := f.client.getBody(ctx, fmt.Sprintf("%s%s", f.client.BaseURL, film.Target))
 	if err != nil {
-		return nil
+		return err
 	}
-	film.ExternalIDs, err = ExtractFilmExternalIDs(bytes.NewReader(b))
+	// Parse the page once, and read everything from the same document
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(b))
 	if err != nil {
 		return err
 	}
-	film.Genres, err = ExtractFilmGenres(bytes.NewReader(b))
+	film.ExternalIDs = filmExternalIDsFromDoc(doc)
+	film.Genres, err = filmGenresFromDoc(doc)
 	if err != nil {
 		return err
 	}
+	if err = filmDetailsFromDoc(doc, film); err != nil {
+		return err
+	}
 
 	return f.getFilmThemesWithPreview(ctx, film)
 }
 
 func (f *FilmServiceOp) getFilmThemesWithPreview(ctx context.Context, film *Film) error {
-	b, err := f.client.getBody(fmt.Sprintf("%s%s/themes", f.client.BaseURL, film.Target))
+	b, err := f.client.getBody(ctx, fmt.Sprintf("%s%s/themes", f.client.BaseURL, film.Target))
 	if err != nil {
-		return nil
+		return err
 	}
 	film.Themes, err = ExtractFilmThemes(bytes.NewReader(b))
 	if err != nil {
@@ -365,11 +440,15 @@ func (f *FilmServiceOp) GetExternalIDs(ctx context.Context, film *Film) error {
 */
 
 func ExtractFilmExternalIDs(r io.Reader) (*ExternalFilmIDs, error) {
-	ids := &ExternalFilmIDs{}
 	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
 		return nil, err
 	}
+	return filmExternalIDsFromDoc(doc), nil
+}
+
+func filmExternalIDsFromDoc(doc *goquery.Document) *ExternalFilmIDs {
+	ids := &ExternalFilmIDs{}
 	doc.Find("a").Each(func(i int, s *goquery.Selection) {
 		if val, ok := s.Attr("data-track-action"); ok && val == "IMDb" {
 			ids.IMDB = extractIDFromURL(s.AttrOr("href", ""))
@@ -379,7 +458,7 @@ func ExtractFilmExternalIDs(r io.Reader) (*ExternalFilmIDs, error) {
 		}
 	})
 
-	return ids, nil
+	return ids
 }
 
 func ExtractFilmThemes(r io.Reader) ([]string, error) {
@@ -402,28 +481,106 @@ func ExtractFilmThemes(r io.Reader) ([]string, error) {
 }
 
 func ExtractFilmGenres(r io.Reader) ([]string, error) {
-	genres := []string{}
 	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
 		return nil, err
 	}
-	doc.Find("script").Each(func(i int, s *goquery.Selection) {
-		if val, ok := s.Attr("type"); ok && val == "application/ld+json" {
-			pieces := strings.Split(s.Text(), "\n")
-			if len(pieces) >= 3 {
-				var cdata CDATAFilm
-				err := json.Unmarshal([]byte(pieces[2]), &cdata)
-				if err != nil {
-					panic(err)
-				}
-				genres = cdata.Genre
-			}
+	return filmGenresFromDoc(doc)
+}
+
+func filmGenresFromDoc(doc *goquery.Document) ([]string, error) {
+	cdata, err := filmCDATAFromDoc(doc)
+	if err != nil {
+		return nil, err
+	}
+	if len(cdata.Genre) == 0 {
+		return nil, &ParseError{Selector: `script[type="application/ld+json"] genre`}
+	}
+	return cdata.Genre, nil
+}
+
+// ExtractFilmCDATA returns the JSON-LD blob embedded in a film page
+func ExtractFilmCDATA(r io.Reader) (*CDATAFilm, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return filmCDATAFromDoc(doc)
+}
+
+func filmCDATAFromDoc(doc *goquery.Document) (*CDATAFilm, error) {
+	var cdata *CDATAFilm
+	var err error
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		// The JSON is wrapped in CDATA comments, one per line
+		pieces := strings.Split(s.Text(), "\n")
+		if len(pieces) < 3 {
+			return true
 		}
+		cdata = &CDATAFilm{}
+		err = json.Unmarshal([]byte(pieces[2]), cdata)
+		return false
 	})
-	if len(genres) == 0 {
-		return nil, errors.New("No genres found")
+	if err != nil || cdata == nil {
+		return nil, &ParseError{Selector: `script[type="application/ld+json"]`, Err: err}
+	}
+	return cdata, nil
+}
+
+// ExtractFilmDetails fills in the year, people, runtime, rating, poster and
+// synopsis for a film from its film page
+func ExtractFilmDetails(r io.Reader, film *Film) error {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return err
+	}
+	return filmDetailsFromDoc(doc, film)
+}
+
+var runtimeRegex = regexp.MustCompile(`(\d+)[\s\x{00a0}]*mins?`)
+
+func filmDetailsFromDoc(doc *goquery.Document, film *Film) error {
+	cdata, err := filmCDATAFromDoc(doc)
+	if err != nil {
+		return err
+	}
+	film.Directors = nil
+	for _, d := range cdata.Director {
+		film.Directors = append(film.Directors, d.Name)
 	}
-	return genres, nil
+	film.Cast = nil
+	for i, a := range cdata.Actors {
+		if i >= TopBilledCastSize {
+			break
+		}
+		film.Cast = append(film.Cast, a.Name)
+	}
+	film.Countries = nil
+	for _, c := range cdata.CountryOfOrigin {
+		film.Countries = append(film.Countries, c.Name)
+	}
+	film.Studios = nil
+	for _, p := range cdata.ProductionCompany {
+		film.Studios = append(film.Studios, p.Name)
+	}
+	film.AverageRating = cdata.AggregateRating.RatingValue
+	film.RatingCount = int(cdata.AggregateRating.RatingCount)
+	film.PosterURL = cdata.Image
+
+	// Year is shown next to the title, fall back on the first release
+	if year, err := strconv.Atoi(strings.TrimSpace(doc.Find("small.number a").First().Text())); err == nil {
+		film.Year = year
+	} else if len(cdata.ReleasedEvent) > 0 && len(cdata.ReleasedEvent[0].StartDate) >= 4 {
+		film.Year, _ = strconv.Atoi(cdata.ReleasedEvent[0].StartDate[0:4])
+	}
+
+	// Runtime is in the footer, like '97 mins'
+	if m := runtimeRegex.FindStringSubmatch(doc.Find("p.text-footer").First().Text()); m != nil {
+		film.RuntimeMinutes, _ = strconv.Atoi(m[1])
+	}
+
+	film.Synopsis = strings.TrimSpace(doc.Find("div.review div.truncate p").First().Text())
+	return nil
 }
 
 func extractFilmFromFilmPage(r io.Reader) (interface{}, *Pagination, error) {
@@ -436,8 +593,11 @@ func extractFilmFromFilmPage(r io.Reader) (interface{}, *Pagination, error) {
 	}
 	doc.Find("meta").Each(func(i int, s *goquery.Selection) {
 		if val, ok := s.Attr("property"); ok && val == "og:title" {
+			// Title is suffixed with the year, like 'Title (1971)'
 			fullTitle := s.AttrOr("content", "")
-			f.Title = fullTitle[0 : len(fullTitle)-7]
+			if len(fullTitle) > 7 {
+				f.Title = fullTitle[0 : len(fullTitle)-7]
+			}
 		}
 	})
 	doc.Find("div").Each(func(i int, s *goquery.Selection) {
@@ -463,6 +623,12 @@ func extractFilmFromFilmPage(r io.Reader) (interface{}, *Pagination, error) {
 			f.ExternalIDs.TMDB = extractIDFromURL(s.AttrOr("href", ""))
 		}
 	})
+	if f.Slug == "" {
+		return nil, nil, &ParseError{Selector: "div.film-poster[data-film-slug]"}
+	}
+	if err := filmDetailsFromDoc(doc, f); err != nil {
+		return nil, nil, err
+	}
 	return f, nil, nil
 }
 
@@ -499,6 +665,14 @@ func extractFilmography(r io.Reader) (interface{}, *Pagination, error) {
 	return previews, nil, nil
 }
 
+// GetFilmographyProfessions returns the professions letterboxd has filmography
+// pages for, as used in its URLs. These cover every crew role on a film page
 func GetFilmographyProfessions() []string {
-	return []string{"actor", "director", "producer", "writer"}
+	return []string{
+		"actor", "director", "co-director", "producer", "executive-producer", "writer", "original-writer",
+		"story", "casting", "editor", "cinematography", "camera-operator", "additional-photography",
+		"assistant-director", "additional-directing", "lighting", "production-design", "art-direction",
+		"set-decoration", "special-effects", "visual-effects", "title-design", "stunts", "choreography",
+		"composer", "songs", "sound", "costume-design", "makeup", "hairstyling",
+	}
 }
@@ -1,6 +1,7 @@
 package letterboxd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -78,6 +79,28 @@ func TestExtractFilmFromFilmPage(t *testing.T) {
 	require.Equal(t, "sweet-sweetbacks-baadasssss-song", film.Slug)
 	require.Equal(t, "/film/sweet-sweetbacks-baadasssss-song/", film.Target)
 	require.Equal(t, "48640", film.ID)
+	require.Equal(t, 1971, film.Year)
+	require.Equal(t, 97, film.RuntimeMinutes)
+}
+
+func TestExtractFilmDetails(t *testing.T) {
+	f, err := os.Open("testdata/film/sweetback.html")
+	defer f.Close()
+	require.NoError(t, err)
+	film := &Film{}
+	err = ExtractFilmDetails(f, film)
+	require.NoError(t, err)
+	require.Equal(t, 1971, film.Year)
+	require.Equal(t, []string{"Melvin Van Peebles"}, film.Directors)
+	require.Equal(t, TopBilledCastSize, len(film.Cast))
+	require.Equal(t, "Simon Chuckster", film.Cast[0])
+	require.Equal(t, []string{"USA"}, film.Countries)
+	require.Equal(t, []string{"Yeah"}, film.Studios)
+	require.Equal(t, 97, film.RuntimeMinutes)
+	require.Equal(t, 3.21, film.AverageRating)
+	require.Equal(t, 5914, film.RatingCount)
+	require.Contains(t, film.PosterURL, "48640-sweet-sweetback-s-baadasssss-song")
+	require.True(t, strings.HasPrefix(film.Synopsis, "After saving a Black Panther"))
 }
 
 func TestEnhanceFilmList(t *testing.T) {
@@ -107,26 +130,26 @@ func TestEnhanceFilmList(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	client := NewScrapeClient(nil)
-	client.BaseURL = srv.URL
+	client := newTestClient(srv.URL)
 
 	user := "dave"
 	slug := "official-top-250-narrative-feature-films"
-	films, err := client.List.ListFilms(nil, &ListFilmsOpt{
+	list, err := client.List.ListFilms(context.Background(), &ListFilmsOpt{
 		User:      user,
 		Slug:      slug,
 		FirstPage: 1,
 		LastPage:  1,
 	})
 	require.NoError(t, err)
-	require.NotNil(t, films)
+	require.NotNil(t, list)
+	films := list.Films()
 	require.Equal(t, 100, len(films))
 
 	// Make sure we don't get the external ids on a normal call
 	// require.Nil(t, films[0].ExternalIDs)
 
 	// Make sure we DO get them after enhancing
-	// err = client.Film.EnhanceFilmList(nil, &films)
+	// err = client.Film.EnhanceFilmList(context.Background(), &films)
 	// require.NoError(t, err)
 	require.NotNil(t, films[0].ExternalIDs)
 }
@@ -157,12 +180,11 @@ func TestFilmography(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	client := NewScrapeClient(nil)
-	client.BaseURL = srv.URL
+	client := newTestClient(srv.URL)
 
 	profession := "actor"
 	person := "nicolas-cage"
-	films, err := client.Film.Filmography(nil, &FilmographyOpt{
+	films, err := client.Film.Filmography(context.Background(), &FilmographyOpt{
 		Person:     person,
 		Profession: profession,
 	})
@@ -237,14 +259,13 @@ func TestStreamBatchWithChan(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	client := NewScrapeClient(nil)
-	client.BaseURL = srv.URL
+	client := newTestClient(srv.URL)
 
 	log.Info("Streaming movies")
 	watchedC := make(chan *Film, 0)
 	var watched []*Film
 	done := make(chan error)
-	go client.Film.StreamBatchWithChan(nil, &FilmBatchOpts{
+	go client.Film.StreamBatchWithChan(context.Background(), &FilmBatchOpts{
 		Watched: []string{"someguy"},
 		Lists: []*ListID{
 			{"dave", "official-top-250-narrative-feature-films"},
@@ -264,5 +285,70 @@ loop:
 	}
 
 	require.NotEmpty(t, watched)
-	require.Equal(t, 571, len(watched))
+	// Films in both the watched history and the list are only sent once
+	require.Equal(t, 564, len(watched))
+	seen := map[string]bool{}
+	var both int
+	for _, film := range watched {
+		require.False(t, seen[film.Slug], film.Slug)
+		seen[film.Slug] = true
+		if len(film.Sources) == 2 {
+			require.Equal(t, []string{"watched:someguy", "list:dave/official-top-250-narrative-feature-films"}, film.Sources)
+			both++
+		} else {
+			require.Len(t, film.Sources, 1)
+		}
+	}
+	require.NotZero(t, both)
+}
+
+func TestPreviewPages(t *testing.T) {
+	srv := newFilmSetTestServer(t)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	films, err := client.Film.PreviewPages(context.Background(), "someguy/films")
+	require.NoError(t, err)
+	require.Equal(t, 321, len(films))
+	require.Empty(t, films[0].Genres)
+
+	_, err = client.Film.PreviewPages(context.Background(), "nobody/films")
+	require.Error(t, err)
+}
+
+func TestGetByExternalID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/imdb/tt0067810/", "/tmdb/5822/":
+			http.Redirect(w, r, "/film/sweet-sweetbacks-baadasssss-song/", http.StatusFound)
+		case "/film/sweet-sweetbacks-baadasssss-song/":
+			rp, err := os.Open("testdata/film/sweetback.html")
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	film, err := client.Film.GetByIMDB(context.Background(), "tt0067810")
+	require.NoError(t, err)
+	require.Equal(t, "sweet-sweetbacks-baadasssss-song", film.Slug)
+	require.Equal(t, "tt0067810", film.ExternalIDs.IMDB)
+
+	film, err = client.Film.GetByTMDB(context.Background(), "5822")
+	require.NoError(t, err)
+	require.Equal(t, "sweet-sweetbacks-baadasssss-song", film.Slug)
+	require.Equal(t, "5822", film.ExternalIDs.TMDB)
+
+	_, err = client.Film.GetByIMDB(context.Background(), "tt9999999")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = client.Film.GetByIMDB(context.Background(), "0067810")
+	require.ErrorIs(t, err, ErrInvalidID)
+	_, err = client.Film.GetByTMDB(context.Background(), "tt0067810")
+	require.ErrorIs(t, err, ErrInvalidID)
 }
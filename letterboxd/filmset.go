@@ -0,0 +1,122 @@
+package letterboxd
+
+// FilmSet is a set of films, each kept once no matter how many sources it
+// came from. Films are matched by ID, or by slug when the ID isn't known, and
+// remember every source they came from in Film.Sources. Films keep the order
+// they were first added in
+type FilmSet struct {
+	films []*Film
+	index map[string]int // 'id:' or 'slug:' keys to the position in films
+}
+
+// NewFilmSet returns a set of films, with duplicates merged
+func NewFilmSet(films ...*Film) *FilmSet {
+	s := &FilmSet{index: map[string]int{}}
+	for _, film := range films {
+		s.Add(film)
+	}
+	return s
+}
+
+func filmSetKeys(film *Film) []string {
+	var keys []string
+	if film.ID != "" {
+		keys = append(keys, "id:"+film.ID)
+	}
+	if film.Slug != "" {
+		keys = append(keys, "slug:"+film.Slug)
+	}
+	return keys
+}
+
+func (s *FilmSet) find(film *Film) (int, bool) {
+	for _, key := range filmSetKeys(film) {
+		if i, ok := s.index[key]; ok {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Add puts a copy of film in the set. If the film is already there, its
+// sources are merged instead
+func (s *FilmSet) Add(film *Film) {
+	if i, ok := s.find(film); ok {
+		existing := s.films[i]
+		existing.Sources = mergeSources(existing.Sources, film.Sources)
+		// Fill in whichever key was missing, so later lookups by it match
+		if existing.ID == "" && film.ID != "" {
+			existing.ID = film.ID
+			s.index["id:"+film.ID] = i
+		}
+		return
+	}
+	f := *film
+	f.Sources = mergeSources(nil, film.Sources)
+	s.films = append(s.films, &f)
+	for _, key := range filmSetKeys(&f) {
+		s.index[key] = len(s.films) - 1
+	}
+}
+
+// Contains reports if film is in the set
+func (s *FilmSet) Contains(film *Film) bool {
+	_, ok := s.find(film)
+	return ok
+}
+
+// Films returns the films in the set, in the order they were added
+func (s *FilmSet) Films() []*Film {
+	return s.films
+}
+
+func (s *FilmSet) Len() int {
+	return len(s.films)
+}
+
+// Union returns the films in either set
+func (s *FilmSet) Union(o *FilmSet) *FilmSet {
+	ret := NewFilmSet(s.films...)
+	for _, film := range o.films {
+		ret.Add(film)
+	}
+	return ret
+}
+
+// Intersect returns the films in both sets, with the sources from both
+func (s *FilmSet) Intersect(o *FilmSet) *FilmSet {
+	ret := NewFilmSet()
+	for _, film := range s.films {
+		if i, ok := o.find(film); ok {
+			ret.Add(film)
+			ret.Add(o.films[i])
+		}
+	}
+	return ret
+}
+
+// Difference returns the films in s that aren't in o
+func (s *FilmSet) Difference(o *FilmSet) *FilmSet {
+	ret := NewFilmSet()
+	for _, film := range s.films {
+		if !o.Contains(film) {
+			ret.Add(film)
+		}
+	}
+	return ret
+}
+
+// mergeSources adds the sources in add to sources, skipping any it already
+// has
+func mergeSources(sources, add []string) []string {
+	ret := append([]string{}, sources...)
+	for _, source := range add {
+		if !StringInSlice(source, ret) {
+			ret = append(ret, source)
+		}
+	}
+	if len(ret) == 0 {
+		return nil
+	}
+	return ret
+}
@@ -0,0 +1,346 @@
+package letterboxd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/apex/log"
+)
+
+// FilmSetExpr is a parsed film set expression, combining film sources with
+// set operators, like 'list:dave/imdb-top-250 - watched:alice'.
+//
+// Sources are 'watched:USER', 'watchlist:USER', 'list:USER/SLUG' and
+// 'group:NAME', where NAME is a group in the client's list catalog. The
+// operators are '+' (or '|') for union, '&' for intersection and '-' for
+// difference. '&' binds tighter than '+' and '-', which run left to right.
+// Parentheses group. Put spaces around '-', since slugs have dashes in them
+type FilmSetExpr struct {
+	raw  string
+	root filmSetNode
+}
+
+type filmSetNode interface {
+	eval(leaves map[string]*FilmSet) *FilmSet
+	sources() []*filmSource
+}
+
+// filmSource is a single source of films, like 'watched:alice'
+type filmSource struct {
+	kind string
+	arg  string
+}
+
+func (s *filmSource) label() string {
+	return s.kind + ":" + s.arg
+}
+
+func (s *filmSource) eval(leaves map[string]*FilmSet) *FilmSet {
+	return leaves[s.label()]
+}
+
+func (s *filmSource) sources() []*filmSource {
+	return []*filmSource{s}
+}
+
+type filmSetOp struct {
+	op          rune
+	left, right filmSetNode
+}
+
+func (o *filmSetOp) eval(leaves map[string]*FilmSet) *FilmSet {
+	left, right := o.left.eval(leaves), o.right.eval(leaves)
+	switch o.op {
+	case '&':
+		return left.Intersect(right)
+	case '-':
+		return left.Difference(right)
+	default:
+		return left.Union(right)
+	}
+}
+
+func (o *filmSetOp) sources() []*filmSource {
+	return append(o.left.sources(), o.right.sources()...)
+}
+
+func (e *FilmSetExpr) String() string {
+	return e.raw
+}
+
+// Sources returns the label of each source in the expression, once each
+func (e *FilmSetExpr) Sources() []string {
+	var ret []string
+	for _, source := range e.root.sources() {
+		if !StringInSlice(source.label(), ret) {
+			ret = append(ret, source.label())
+		}
+	}
+	return ret
+}
+
+// unionFilmSetExpr returns an expression for the union of sources, or nil if
+// there are none
+func unionFilmSetExpr(sources []*filmSource) *FilmSetExpr {
+	if len(sources) == 0 {
+		return nil
+	}
+	var root filmSetNode = sources[0]
+	labels := []string{sources[0].label()}
+	for _, source := range sources[1:] {
+		root = &filmSetOp{op: '+', left: root, right: source}
+		labels = append(labels, source.label())
+	}
+	return &FilmSetExpr{raw: strings.Join(labels, " + "), root: root}
+}
+
+type filmSetToken struct {
+	pos  int
+	text string // An operator, a parenthesis or a source
+}
+
+// ParseFilmSetExpr parses an expression like 'list:dave/imdb-top-250 -
+// watched:alice'. See FilmSetExpr for the syntax
+func ParseFilmSetExpr(s string) (*FilmSetExpr, error) {
+	tokens, err := tokenizeFilmSetExpr(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &filmSetParser{tokens: tokens}
+	root, err := p.parseUnion()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != nil {
+		return nil, fmt.Errorf("unexpected %q at %v in film set expression", t.text, t.pos)
+	}
+	return &FilmSetExpr{raw: strings.TrimSpace(s), root: root}, nil
+}
+
+func tokenizeFilmSetExpr(s string) ([]*filmSetToken, error) {
+	var tokens []*filmSetToken
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("()+|&-", r):
+			// A dash can only start an operator, since sources never start
+			// with one
+			tokens = append(tokens, &filmSetToken{pos: i, text: string(r)})
+			i++
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("()+|&", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, &filmSetToken{pos: start, text: string(runes[start:i])})
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("film set expression is empty")
+	}
+	return tokens, nil
+}
+
+type filmSetParser struct {
+	tokens []*filmSetToken
+	next   int
+}
+
+func (p *filmSetParser) peek() *filmSetToken {
+	if p.next >= len(p.tokens) {
+		return nil
+	}
+	return p.tokens[p.next]
+}
+
+// parseUnion parses terms joined by '+', '|' or '-'
+func (p *filmSetParser) parseUnion() (filmSetNode, error) {
+	left, err := p.parseIntersect()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t != nil && (t.text == "+" || t.text == "|" || t.text == "-"); t = p.peek() {
+		p.next++
+		right, err := p.parseIntersect()
+		if err != nil {
+			return nil, err
+		}
+		op := '+'
+		if t.text == "-" {
+			op = '-'
+		}
+		left = &filmSetOp{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseIntersect parses terms joined by '&'
+func (p *filmSetParser) parseIntersect() (filmSetNode, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t != nil && t.text == "&"; t = p.peek() {
+		p.next++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &filmSetOp{op: '&', left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseTerm parses a source, or an expression in parentheses
+func (p *filmSetParser) parseTerm() (filmSetNode, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, fmt.Errorf("film set expression ends early, expected a source")
+	}
+	p.next++
+	switch t.text {
+	case "(":
+		node, err := p.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.peek(); c == nil || c.text != ")" {
+			return nil, fmt.Errorf("missing ')' for the '(' at %v in film set expression", t.pos)
+		}
+		p.next++
+		return node, nil
+	case ")", "+", "|", "&", "-":
+		return nil, fmt.Errorf("unexpected %q at %v in film set expression, expected a source", t.text, t.pos)
+	}
+	return parseFilmSource(t)
+}
+
+func parseFilmSource(t *filmSetToken) (*filmSource, error) {
+	kind, arg, ok := strings.Cut(t.text, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid source %q at %v in film set expression, expected something like 'watched:USER'", t.text, t.pos)
+	}
+	switch kind {
+	case "watched", "watchlist":
+		if !listUserRegex.MatchString(arg) {
+			return nil, fmt.Errorf("invalid user %q at %v in film set expression", arg, t.pos)
+		}
+	case "list":
+		user, slug, _ := strings.Cut(arg, "/")
+		if err := (&ListID{User: user, Slug: slug}).Validate(); err != nil {
+			return nil, fmt.Errorf("%w at %v in film set expression", err, t.pos)
+		}
+	case "group":
+		if arg == "" {
+			return nil, fmt.Errorf("group at %v in film set expression needs a name", t.pos)
+		}
+	default:
+		return nil, fmt.Errorf("unknown source %q at %v in film set expression, must be one of watched, watchlist, list or group", kind, t.pos)
+	}
+	return &filmSource{kind: kind, arg: arg}, nil
+}
+
+// EvalFilmSetExpr fetches every source in the expression, then works out the
+// films it describes. Sources are fetched without film details, and only the
+// films in the result are enhanced, so subtracting a big watched history is
+// cheap
+func (f *FilmServiceOp) EvalFilmSetExpr(ctx context.Context, expr *FilmSetExpr) (*FilmSet, error) {
+	var sources []*filmSource
+	for _, source := range expr.root.sources() {
+		if !filmSourceInSlice(source, sources) {
+			sources = append(sources, source)
+		}
+	}
+	leaves := make(map[string]*FilmSet, len(sources))
+	errs := make([]error, len(sources))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source *filmSource) {
+			defer wg.Done()
+			set, err := f.sourceFilms(ctx, source)
+			if err != nil {
+				errs[i] = fmt.Errorf("%v: %w", source.label(), err)
+				return
+			}
+			mu.Lock()
+			leaves[source.label()] = set
+			mu.Unlock()
+		}(i, source)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := expr.root.eval(leaves)
+	films := result.Films()
+	if err := f.EnhanceFilmList(ctx, &films); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func filmSourceInSlice(s *filmSource, sources []*filmSource) bool {
+	for _, b := range sources {
+		if *b == *s {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceFilms fetches the films for a single source, tagging each with where
+// it came from
+func (f *FilmServiceOp) sourceFilms(ctx context.Context, source *filmSource) (*FilmSet, error) {
+	var lists []*ListID
+	switch source.kind {
+	case "watched":
+		return f.taggedFilmPages(ctx, source.label(), source.arg+"/films")
+	case "watchlist":
+		return f.taggedFilmPages(ctx, source.label(), source.arg+"/watchlist")
+	case "list":
+		user, slug, _ := strings.Cut(source.arg, "/")
+		lists = []*ListID{{User: user, Slug: slug}}
+	case "group":
+		var err error
+		if lists, err = f.client.catalog.Lists(source.arg); err != nil {
+			return nil, err
+		}
+	}
+	set := NewFilmSet()
+	for _, list := range lists {
+		listSet, err := f.taggedFilmPages(ctx, "list:"+list.String(), list.User+"/list/"+list.Slug)
+		if err != nil {
+			return nil, err
+		}
+		set = set.Union(listSet)
+	}
+	return set, nil
+}
+
+// taggedFilmPages fetches every page of a film grid, without the film details
+func (f *FilmServiceOp) taggedFilmPages(ctx context.Context, label string, path string) (*FilmSet, error) {
+	films, err := f.PreviewPages(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	set := NewFilmSet()
+	for _, film := range films {
+		film.Sources = []string{label}
+		set.Add(film)
+	}
+	log.WithFields(log.Fields{
+		"source": label,
+		"films":  set.Len(),
+	}).Debug("Fetched film source")
+	return set, nil
+}
@@ -0,0 +1,200 @@
+package letterboxd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setFilm(id, slug string, sources ...string) *Film {
+	return &Film{ID: id, Slug: slug, Sources: sources}
+}
+
+func filmSetSlugs(s *FilmSet) []string {
+	slugs := []string{}
+	for _, film := range s.Films() {
+		slugs = append(slugs, film.Slug)
+	}
+	return slugs
+}
+
+func TestFilmSet(t *testing.T) {
+	a := NewFilmSet(setFilm("1", "alien", "watched:alice"), setFilm("2", "heat", "watched:alice"), setFilm("1", "alien", "watched:alice"))
+	require.Equal(t, 2, a.Len())
+	require.Equal(t, []string{"watched:alice"}, a.Films()[0].Sources)
+
+	// Matched by ID, even if the slug is missing
+	b := NewFilmSet(setFilm("1", "", "list:dave/top"), setFilm("", "jaws", "list:dave/top"))
+	require.True(t, a.Contains(setFilm("", "heat")))
+	require.True(t, b.Contains(setFilm("1", "alien")))
+
+	union := a.Union(b)
+	require.Equal(t, []string{"alien", "heat", "jaws"}, filmSetSlugs(union))
+	require.Equal(t, []string{"watched:alice", "list:dave/top"}, union.Films()[0].Sources)
+
+	intersect := a.Intersect(b)
+	require.Equal(t, []string{"alien"}, filmSetSlugs(intersect))
+	require.Equal(t, []string{"watched:alice", "list:dave/top"}, intersect.Films()[0].Sources)
+
+	require.Equal(t, []string{"heat"}, filmSetSlugs(a.Difference(b)))
+	require.Equal(t, []string{"jaws"}, filmSetSlugs(b.Difference(a)))
+
+	// Sets copy their films, so the operations leave the originals alone
+	require.Equal(t, []string{"watched:alice"}, a.Films()[0].Sources)
+}
+
+func TestParseFilmSetExpr(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    string
+		sources []string
+	}{
+		{"watched:alice", "watched:alice", []string{"watched:alice"}},
+		{"list:dave/imdb-top-250 - watched:alice", "(list:dave/imdb-top-250 - watched:alice)", []string{"list:dave/imdb-top-250", "watched:alice"}},
+		{"watchlist:a + watchlist:b & watched:c", "(watchlist:a + (watchlist:b & watched:c))", []string{"watchlist:a", "watchlist:b", "watched:c"}},
+		{"(watchlist:a|watchlist:b)&watched:c", "((watchlist:a + watchlist:b) & watched:c)", []string{"watchlist:a", "watchlist:b", "watched:c"}},
+		{"group:horror - watched:a - watched:b", "((group:horror - watched:a) - watched:b)", []string{"group:horror", "watched:a", "watched:b"}},
+		{"watched:a - watched:a", "(watched:a - watched:a)", []string{"watched:a"}},
+	}
+	for _, tt := range tests {
+		expr, err := ParseFilmSetExpr(tt.expr)
+		require.NoError(t, err, tt.expr)
+		require.Equal(t, tt.want, filmSetNodeString(expr.root), tt.expr)
+		require.Equal(t, tt.sources, expr.Sources(), tt.expr)
+		require.Equal(t, tt.expr, expr.String())
+	}
+}
+
+func filmSetNodeString(n filmSetNode) string {
+	switch n := n.(type) {
+	case *filmSource:
+		return n.label()
+	case *filmSetOp:
+		return fmt.Sprintf("(%v %c %v)", filmSetNodeString(n.left), n.op, filmSetNodeString(n.right))
+	}
+	return ""
+}
+
+func TestParseFilmSetExprErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"  ",
+		"alice",
+		"watched:",
+		"ratings:alice",
+		"list:dave",
+		"group:",
+		"watched:alice -",
+		"- watched:alice",
+		"watched:alice watched:bob",
+		"(watched:alice",
+		"watched:alice)",
+		"watched:alice & & watched:bob",
+	} {
+		_, err := ParseFilmSetExpr(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func newFilmSetTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var path string
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/someguy/films/page/"):
+			path = fmt.Sprintf("testdata/user/watched-paginated/%v.html", strings.Split(r.URL.Path, "/")[4])
+		case strings.HasPrefix(r.URL.Path, "/dave/list/official-top-250-narrative-feature-films/page/"):
+			path = fmt.Sprintf("testdata/list/lists-page-%v.html", strings.Split(r.URL.Path, "/")[5])
+		case strings.HasPrefix(r.URL.Path, "/film/"):
+			path = "testdata/film/sweetback.html"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open(path)
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+}
+
+func TestEvalFilmSetExpr(t *testing.T) {
+	srv := newFilmSetTestServer(t)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+	client.catalog = &ListCatalog{Groups: []*ListGroup{
+		{Name: "top", Lists: []*ListID{{"dave", "official-top-250-narrative-feature-films"}}},
+	}}
+	ctx := context.Background()
+
+	eval := func(s string) *FilmSet {
+		expr, err := ParseFilmSetExpr(s)
+		require.NoError(t, err)
+		set, err := client.Film.EvalFilmSetExpr(ctx, expr)
+		require.NoError(t, err)
+		return set
+	}
+	list := eval("list:dave/official-top-250-narrative-feature-films")
+	watched := eval("watched:someguy")
+	require.Equal(t, "list:dave/official-top-250-narrative-feature-films", list.Films()[0].Sources[0])
+	// Only the result is enhanced
+	require.NotEmpty(t, list.Films()[0].Genres)
+
+	unseen := eval("list:dave/official-top-250-narrative-feature-films - watched:someguy")
+	both := eval("group:top & watched:someguy")
+	require.NotZero(t, unseen.Len())
+	require.NotZero(t, both.Len())
+	require.Equal(t, list.Len(), unseen.Len()+both.Len())
+	for _, film := range unseen.Films() {
+		require.Equal(t, []string{"list:dave/official-top-250-narrative-feature-films"}, film.Sources)
+	}
+	for _, film := range both.Films() {
+		require.Equal(t, []string{"list:dave/official-top-250-narrative-feature-films", "watched:someguy"}, film.Sources)
+	}
+	require.Equal(t, list.Len()+watched.Len()-both.Len(), eval("watched:someguy + group:top").Len())
+
+	expr, err := ParseFilmSetExpr("group:westerns - watched:someguy")
+	require.NoError(t, err)
+	_, err = client.Film.EvalFilmSetExpr(ctx, expr)
+	require.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestStreamBatchExpression(t *testing.T) {
+	srv := newFilmSetTestServer(t)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	filmC := make(chan *Film)
+	done := make(chan error)
+	go client.Film.StreamBatchWithChan(context.Background(), &FilmBatchOpts{
+		Expression: "list:dave/official-top-250-narrative-feature-films + watched:someguy",
+	}, filmC, done)
+	seen := map[string]bool{}
+loop:
+	for {
+		select {
+		case film := <-filmC:
+			require.False(t, seen[film.Slug], film.Slug)
+			seen[film.Slug] = true
+			require.NotEmpty(t, film.Sources)
+		case err := <-done:
+			require.NoError(t, err)
+			break loop
+		}
+	}
+	require.NotEmpty(t, seen)
+
+	go client.Film.StreamBatchWithChan(context.Background(), &FilmBatchOpts{
+		Watched:    []string{"someguy"},
+		Expression: "watched:someguy",
+	}, filmC, done)
+	require.Error(t, <-done)
+}
@@ -6,23 +6,61 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/apex/log"
 )
 
 type ListService interface {
-	ListFilms(context.Context, *ListFilmsOpt) ([]*Film, error)
+	ListFilms(context.Context, *ListFilmsOpt) (*List, error)
 	GetOfficial(context.Context) []*ListID
+	Catalog(context.Context) *ListCatalog
+	ByUser(context.Context, string) ([]*List, error)
 }
 
 type ListServiceOp struct {
 	client *ScrapeClient
 }
 
+// ListID points at a user's list, like 'dave/imdb-top-250'
 type ListID struct {
-	User string
-	Slug string
+	User string `json:"user" yaml:"user"`
+	Slug string `json:"slug" yaml:"slug"`
+}
+
+// List is a user's list. Entries are only filled in by ListFilms
+type List struct {
+	ID          string       `json:"id,omitempty"`
+	User        string       `json:"user"`
+	AuthorName  string       `json:"author_name,omitempty"` // Display name of the list owner
+	Slug        string       `json:"slug"`
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+	FilmCount   int          `json:"film_count"`
+	Likes       int          `json:"likes"`
+	Ranked      bool         `json:"ranked"`
+	Updated     *time.Time   `json:"updated,omitempty"`
+	Entries     []*ListEntry `json:"entries,omitempty"`
+}
+
+// ListEntry is a film on a list, along with where it sits and what the list
+// owner wrote about it
+type ListEntry struct {
+	Position int    `json:"position"` // 1 based, or 0 if it couldn't be worked out. Only meaningful as a rank when the list is Ranked
+	Film     *Film  `json:"film"`
+	Note     string `json:"note,omitempty"`
+}
+
+// Films returns just the films from the list entries, in list order
+func (l *List) Films() []*Film {
+	films := make([]*Film, 0, len(l.Entries))
+	for _, entry := range l.Entries {
+		films = append(films, entry.Film)
+	}
+	return films
 }
 
 // ListFilmsOpt is the options for the ListFilms method
@@ -31,55 +69,92 @@ type ListFilmsOpt struct {
 	Slug      string // Slug of the list: Example: 'official-top-250-narrative-feature-films'
 	FirstPage int    // First page to fetch. Defaults to 1
 	LastPage  int    // Last page to fetch. Defaults to FirstPage. Use -1 to fetch all pages
+	Notes     bool   // Fetch the detail view of the list, which includes the notes for each entry
+	Previews  bool   // Skip looking up each film's details, which takes 2 more requests per film
 }
 
+// GetOfficial returns every list in the client's catalog
+//
+// Deprecated: Use Catalog, which keeps the lists in their groups
 func (l *ListServiceOp) GetOfficial(ctx context.Context) []*ListID {
-	return []*ListID{
-		{User: "crew", Slug: "edgar-wrights-1000-favorite-movies"},
-		{User: "darrencb", Slug: "letterboxds-top-250-horror-films"},
-		{User: "dave", Slug: "official-top-250-narrative-feature-films"},
-		{User: "dave", Slug: "imdb-top-250"},
-		{User: "gubarenko", Slug: "1001-movies-you-must-see-before-you-die-2021"},
-		{User: "jack", Slug: "official-top-250-documentary-films"},
-		{User: "jack", Slug: "women-directors-the-official-top-250-narrative"},
-		{User: "jake_ziegler", Slug: "academy-award-winners-for-best-picture"},
-		{User: "lifeasfiction", Slug: "letterboxd-100-animation/"},
-		{User: "liveandrew", Slug: "bfi-2012-critics-top-250-films"},
-		{User: "matthew", Slug: "box-office-mojo-all-time-worldwide"},
-		{User: "moseschan", Slug: "afi-100-years-100-movies"},
-	}
+	lists, _ := l.client.catalog.Lists()
+	return lists
 }
 
-func (l *ListServiceOp) ListFilms(ctx context.Context, opt *ListFilmsOpt) ([]*Film, error) {
-	var films []*Film
+// Catalog returns the curated lists the client knows about
+func (l *ListServiceOp) Catalog(ctx context.Context) *ListCatalog {
+	return l.client.catalog
+}
+
+// ListFilms returns a list with its metadata and the entries from the
+// requested pages
+func (l *ListServiceOp) ListFilms(ctx context.Context, opt *ListFilmsOpt) (*List, error) {
+	var list *List
 
 	startPage, stopPage, err := normalizeStartStop(opt.FirstPage, opt.LastPage)
 	if err != nil {
 		return nil, err
 	}
+	view := ""
+	if opt.Notes {
+		view = "detail/"
+	}
 
+	// offset is how many entries come before the page being fetched
+	offset := -1
+	if startPage == 1 {
+		offset = 0
+	}
 	page := startPage
 	for {
-		req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s/list/%s/page/%d", l.client.BaseURL, opt.User, opt.Slug, page), nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s/list/%s/%spage/%d", l.client.BaseURL, opt.User, opt.Slug, view, page), nil)
 		if err != nil {
 			return nil, err
 		}
-		items, _, err := l.client.sendRequest(req, extractListFilms)
+		items, _, err := l.client.sendRequest(req, extractList)
 		if err != nil {
 			return nil, err
 		}
 
-		partialFilms := items.Data.([]*Film)
+		partialList := items.Data.(*List)
+		if offset < 0 {
+			// Every page but the last is full, and the last one ends the list
+			if !items.Pagintion.IsLast {
+				offset = (page - 1) * len(partialList.Entries)
+			} else if partialList.FilmCount > 0 {
+				offset = partialList.FilmCount - len(partialList.Entries)
+			}
+		}
+		// Entries without a number on the page are numbered by where they sit
+		if offset >= 0 {
+			for i, entry := range partialList.Entries {
+				if entry.Position == 0 {
+					entry.Position = offset + i + 1
+				}
+			}
+			offset += len(partialList.Entries)
+		}
+		if !opt.Previews {
+			partialFilms := partialList.Films()
 
-		// This is a bit costly, parallel time?
-		err = l.client.Film.EnhanceFilmList(ctx, &partialFilms)
-		if err != nil {
-			log.WithError(err).Warn("Failed to enhance film list")
-			return nil, err
+			// This is a bit costly, parallel time?
+			err = l.client.Film.EnhanceFilmList(ctx, &partialFilms)
+			if err != nil {
+				log.WithError(err).Warn("Failed to enhance film list")
+				return nil, err
+			}
 		}
 
-		films = append(films, partialFilms...)
+		if list == nil {
+			list = partialList
+		} else {
+			list.Entries = append(list.Entries, partialList.Entries...)
+		}
 		if items.Pagintion.IsLast {
+			// Having fetched the whole list, the entries can be counted
+			if list.FilmCount == 0 && startPage == 1 {
+				list.FilmCount = len(list.Entries)
+			}
 			break
 		}
 		// Set last page to the total number of pages if it's set to -1
@@ -96,41 +171,128 @@ func (l *ListServiceOp) ListFilms(ctx context.Context, opt *ListFilmsOpt) ([]*Fi
 			panic("Too many pages requested, close")
 		}
 	}
-	return films, nil
+	list.User = opt.User
+	list.Slug = opt.Slug
+	return list, nil
+}
+
+// ByUser returns the metadata for every list a user has made
+func (l *ListServiceOp) ByUser(ctx context.Context, userID string) ([]*List, error) {
+	lists := []*List{}
+	for page := 1; page <= maxPages; page++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s/lists/page/%d/", l.client.BaseURL, userID, page), nil)
+		if err != nil {
+			return nil, err
+		}
+		items, _, err := l.client.sendRequest(req, extractUserLists)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, items.Data.([]*List)...)
+		if items.Pagintion.IsLast {
+			return lists, nil
+		}
+	}
+	log.WithField("user", userID).Warn("Stopping lists at the page limit")
+	return lists, nil
 }
 
-func extractListFilms(r io.Reader) (interface{}, *Pagination, error) {
-	var previews []*Film
+func extractUserLists(r io.Reader) (interface{}, *Pagination, error) {
 	var pageBuf bytes.Buffer
 	tee := io.TeeReader(r, &pageBuf)
 	doc, err := goquery.NewDocumentFromReader(tee)
 	if err != nil {
 		return nil, nil, err
 	}
-	doc.Find("li.poster-container").Each(func(i int, s *goquery.Selection) {
-		s.Find("div").Each(func(i int, s *goquery.Selection) {
-			if s.HasClass("film-poster") {
-				f := Film{}
-				f.ID = s.AttrOr("data-film-id", "")
-				f.Slug = normalizeSlug(s.AttrOr("data-film-slug", ""))
-				f.Target = s.AttrOr("data-target-link", "")
-				// Real film name appears in the alt attribute for the poster
-				s.Find("img.image").Each(func(i int, s *goquery.Selection) {
-					f.Title = s.AttrOr("alt", "")
-				})
-				previews = append(previews, &f)
-			}
-		})
+	lists := []*List{}
+	doc.Find("section.list-set section.list").Each(func(i int, s *goquery.Selection) {
+		title := s.Find("div.film-list-summary h2 a").First()
+		// List links look like '/dave/list/imdb-top-250/'
+		parts := strings.Split(strings.Trim(title.AttrOr("href", ""), "/"), "/")
+		if len(parts) != 3 || parts[1] != "list" {
+			return
+		}
+		list := &List{
+			ID:          s.AttrOr("data-film-list-id", ""),
+			User:        parts[0],
+			Slug:        parts[2],
+			Title:       strings.TrimSpace(title.Text()),
+			Description: paragraphsText(s.Find("div.body-text")),
+			Ranked:      s.HasClass("-ranked"),
+		}
+		// Film count looks like '250 films'
+		if fields := strings.Fields(s.Find("div.film-list-summary small.value").Text()); len(fields) > 0 {
+			list.FilmCount = parseCount(fields[0])
+		}
+		list.Likes = parseCount(s.Find("div.film-list-summary a.icon-like span.label").Text())
+		if updated, err := time.Parse(time.RFC3339, s.Find("time").AttrOr("datetime", "")); err == nil {
+			list.Updated = &updated
+		}
+		lists = append(lists, list)
 	})
-	pagination, err := ExtractPaginationWithReader(&pageBuf)
+	return lists, paginationOrSinglePage(&pageBuf), nil
+}
+
+// extractList pulls the list metadata and the entries from a single page of a
+// list. Both the grid view and the detail view are handled, but only the detail
+// view has the entry notes
+func extractList(r io.Reader) (interface{}, *Pagination, error) {
+	var pageBuf bytes.Buffer
+	tee := io.TeeReader(r, &pageBuf)
+	doc, err := goquery.NewDocumentFromReader(tee)
 	if err != nil {
-		log.Debug("No pagination data found")
-		pagination = &Pagination{
-			CurrentPage: 1,
-			NextPage:    1,
-			TotalPages:  1,
-			IsLast:      true,
-		}
+		return nil, nil, err
+	}
+	intro := doc.Find("div.list-title-intro").First()
+	if intro.Length() == 0 {
+		return nil, nil, &ParseError{Selector: "div.list-title-intro"}
+	}
+	list := &List{
+		Title:      strings.TrimSpace(intro.Find("h1.title-1").Text()),
+		AuthorName: strings.TrimSpace(doc.Find("div.person-summary a.name span[itemprop=name]").First().Text()),
+		Entries:    []*ListEntry{},
+	}
+	// Long descriptions are collapsed, with the full text hidden in #list-notes
+	if notes := doc.Find("div#list-notes"); notes.Length() > 0 {
+		list.Description = paragraphsText(notes)
+	} else {
+		list.Description = paragraphsText(intro.Find("div.body-text").First())
+	}
+	doc.Find("ul.tags li a").Each(func(i int, s *goquery.Selection) {
+		list.Tags = append(list.Tags, strings.TrimSpace(s.Text()))
+	})
+	// The page description starts like 'A list of 250 films compiled on Letterboxd'
+	if fields := strings.Fields(doc.Find("meta[name=description]").AttrOr("content", "")); len(fields) > 3 && strings.Join(fields[0:3], " ") == "A list of" {
+		list.FilmCount = parseCount(fields[3])
+	}
+	// The report link looks like '/ajax/filmlist:207314/report-form'
+	if report := doc.Find("[data-report-url*='filmlist:']").AttrOr("data-report-url", ""); report != "" {
+		list.ID = strings.SplitN(strings.SplitN(report, "filmlist:", 2)[1], "/", 2)[0]
 	}
-	return previews, pagination, nil
+
+	doc.Find("li.poster-container, li.film-detail").Each(func(i int, s *goquery.Selection) {
+		poster := s.Find("div.film-poster").First()
+		if poster.Length() == 0 {
+			return
+		}
+		entry := &ListEntry{
+			Film: &Film{
+				ID:     poster.AttrOr("data-film-id", ""),
+				Slug:   normalizeSlug(poster.AttrOr("data-film-slug", "")),
+				Target: poster.AttrOr("data-target-link", ""),
+				// Real film name appears in the alt attribute for the poster
+				Title: poster.Find("img.image").AttrOr("alt", ""),
+			},
+			Note: paragraphsText(s.Find("div.body-text").First()),
+		}
+		if s.HasClass("numbered-list-item") {
+			list.Ranked = true
+		}
+		if number := s.Find("p.list-number"); number.Length() > 0 {
+			list.Ranked = true
+			entry.Position = parseCount(strings.TrimSpace(number.Text()))
+		}
+		list.Entries = append(list.Entries, entry)
+	})
+	return list, paginationOrSinglePage(&pageBuf), nil
 }
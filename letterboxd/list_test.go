@@ -2,6 +2,7 @@ package letterboxd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,26 +10,12 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/apex/log"
 	"github.com/stretchr/testify/require"
 )
 
-func TestExtractListFilms(t *testing.T) {
-	f, err := os.Open("testdata/list/top250.html")
-	defer f.Close()
-	require.NoError(t, err)
-
-	items, _, err := extractListFilms(f)
-	films := items.([]*Film)
-	// films = ret.([]FilmPreview)
-
-	// log.Fatal(films)
-	require.NoError(t, err)
-	require.Greater(t, len(films), 70)
-	require.Equal(t, "Everything Everywhere All at Once", films[0].Title)
-}
-
 func TestListFilms(t *testing.T) {
 	sweetbackF, err := os.Open("testdata/film/sweetback.html")
 	defer sweetbackF.Close()
@@ -55,8 +42,7 @@ func TestListFilms(t *testing.T) {
 		defer r.Body.Close()
 	}))
 	defer lsrv.Close()
-	client := NewScrapeClient(nil)
-	client.BaseURL = lsrv.URL
+	client := newTestClient(lsrv.URL)
 
 	user := "dave"
 	slug := "official-top-250-narrative-feature-films"
@@ -74,7 +60,7 @@ func TestListFilms(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		got, err := client.List.ListFilms(nil, &ListFilmsOpt{
+		got, err := client.List.ListFilms(context.Background(), &ListFilmsOpt{
 			User:      user,
 			Slug:      slug,
 			FirstPage: tt.start,
@@ -82,11 +68,156 @@ func TestListFilms(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.NotNil(t, got)
-		require.Equal(t, tt.wantCount, len(got))
+		require.Equal(t, tt.wantCount, len(got.Entries))
+		require.Equal(t, "Official Top 250 Narrative Feature Films", got.Title)
+		require.Equal(t, 250, got.FilmCount)
+		require.Equal(t, (tt.start-1)*100+1, got.Entries[0].Position)
+	}
+}
+
+func TestExtractList(t *testing.T) {
+	f, err := os.Open("testdata/list/top250.html")
+	defer f.Close()
+	require.NoError(t, err)
+
+	items, _, err := extractList(f)
+	require.NoError(t, err)
+	list := items.(*List)
+	require.Equal(t, "Official Top 250 Narrative Feature Films", list.Title)
+	require.Equal(t, "Dave Vis", list.AuthorName)
+	require.Equal(t, "207314", list.ID)
+	require.Equal(t, 250, list.FilmCount)
+	require.True(t, list.Ranked)
+	require.Contains(t, list.Description, "Letterboxd's Top 250 movies")
+	require.Equal(t, []string{"letterboxd", "top 250", "feature length"}, list.Tags[0:3])
+	require.Greater(t, len(list.Entries), 70)
+	require.Equal(t, "Everything Everywhere All at Once", list.Entries[0].Film.Title)
+	require.Empty(t, list.Entries[0].Note)
+}
+
+func TestExtractListDetail(t *testing.T) {
+	f, err := os.Open("testdata/list/top250-detail.html")
+	defer f.Close()
+	require.NoError(t, err)
+
+	items, _, err := extractList(f)
+	require.NoError(t, err)
+	list := items.(*List)
+	require.True(t, list.Ranked)
+	require.Equal(t, "Letterboxd's Top 250 movies, based on the average weighted rating of all Letterboxd users.\n\nFilms should have a minimum of 5,000 ratings to be eligible to enter the list.", list.Description)
+	require.Equal(t, 3, len(list.Entries))
+	require.Equal(t, &ListEntry{
+		Position: 1,
+		Film: &Film{
+			ID:     "474474",
+			Slug:   "everything-everywhere-all-at-once",
+			Target: "/film/everything-everywhere-all-at-once/",
+			Title:  "Everything Everywhere All at Once",
+		},
+		Note: "Took the top spot in its first month.\n\nBagels, everywhere.",
+	}, list.Entries[0])
+	require.Equal(t, 3, list.Entries[2].Position)
+	require.Empty(t, list.Entries[1].Note)
+	require.Equal(t, "come-and-see", list.Films()[2].Slug)
+}
+
+func TestExtractListUnranked(t *testing.T) {
+	f, err := os.Open("testdata/list/lists-single-page.html")
+	defer f.Close()
+	require.NoError(t, err)
+
+	items, _, err := extractList(f)
+	require.NoError(t, err)
+	list := items.(*List)
+	require.Equal(t, "2022 - Movie Church", list.Title)
+	require.Equal(t, "Drew Stinnett", list.AuthorName)
+	require.False(t, list.Ranked)
+	require.Equal(t, 13, len(list.Entries))
+}
+
+func TestListFilmsUnranked(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/drew/list/2022-movie-church/detail/page/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open("testdata/list/lists-single-page.html")
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	got, err := client.List.ListFilms(context.Background(), &ListFilmsOpt{
+		User:     "drew",
+		Slug:     "2022-movie-church",
+		Notes:    true,
+		Previews: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 13, got.FilmCount)
+	// Entries are numbered by where they sit, whatever the view's page size
+	for i, entry := range got.Entries {
+		require.Equal(t, i+1, entry.Position)
 	}
 }
 
 func TestGetOfficial(t *testing.T) {
-	client := NewScrapeClient(nil)
+	client := NewScrapeClient(nil, nil)
 	require.Greater(t, len(client.List.GetOfficial(context.Background())), 0)
 }
+
+func TestExtractUserLists(t *testing.T) {
+	f, err := os.Open("testdata/list/user-lists-1.html")
+	defer f.Close()
+	require.NoError(t, err)
+	items, pagination, err := extractUserLists(f)
+	require.NoError(t, err)
+	require.Equal(t, 2, pagination.TotalPages)
+	lists := items.([]*List)
+	require.Equal(t, 3, len(lists))
+	updated := time.Date(2022, 4, 20, 15, 2, 11, 0, time.UTC)
+	require.Equal(t, &List{
+		ID:          "1234",
+		User:        "dave",
+		Slug:        "imdb-top-250",
+		Title:       "IMDb Top 250",
+		Description: "The IMDb Top 250, kept up to date every week.\n\nLast update: April 2022.",
+		FilmCount:   250,
+		Likes:       12400,
+		Ranked:      true,
+		Updated:     &updated,
+	}, lists[0])
+	require.Equal(t, 98000, lists[1].Likes)
+	require.Equal(t, 1042, lists[2].FilmCount)
+	require.False(t, lists[2].Ranked)
+	require.Empty(t, lists[2].Description)
+}
+
+func TestListsByUser(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/dave/lists/page/") {
+			rp, err := os.Open(fmt.Sprintf("testdata/list/user-lists-%v.html", strings.Split(r.URL.Path, "/")[4]))
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	lists, err := client.List.ByUser(context.Background(), "dave")
+	require.NoError(t, err)
+	require.Equal(t, 4, len(lists))
+	require.Equal(t, "halloween", lists[3].Slug)
+	require.Nil(t, lists[3].Updated)
+	// Lists without an update time leave it out, rather than claiming year 1
+	b, err := json.Marshal(lists[3])
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "updated")
+}
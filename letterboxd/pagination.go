@@ -2,7 +2,6 @@ package letterboxd
 
 import (
 	"bytes"
-	"errors"
 	"io"
 	"strconv"
 	"strings"
@@ -46,7 +45,7 @@ func ExtractPaginationWithDoc(doc *goquery.Document) (*Pagination, error) {
 		})
 	})
 	if p.CurrentPage == 0 {
-		return nil, errors.New("Could not extract pagination, no current page")
+		return nil, &ParseError{Selector: "div.paginate-pages li.paginate-current"}
 	}
 	if p.CurrentPage == p.TotalPages {
 		p.IsLast = true
@@ -71,3 +70,19 @@ func ExtractPaginationWithReader(r io.Reader) (*Pagination, error) {
 	}
 	return ExtractPaginationWithDoc(doc)
 }
+
+// paginationOrSinglePage reads the pagination from a page, taking a page
+// without any to be the only one
+func paginationOrSinglePage(r io.Reader) *Pagination {
+	pagination, err := ExtractPaginationWithReader(r)
+	if err != nil {
+		log.Debug("No pagination data found, assuming it to be a single page")
+		return &Pagination{
+			CurrentPage: 1,
+			NextPage:    1,
+			TotalPages:  1,
+			IsLast:      true,
+		}
+	}
+	return pagination
+}
@@ -0,0 +1,137 @@
+package letterboxd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/apex/log"
+)
+
+// RatingSteps are the ratings a member can give, in half stars
+var RatingSteps = []float64{0.5, 1, 1.5, 2, 2.5, 3, 3.5, 4, 4.5, 5}
+
+// RatingBucket is the number of films given a single rating
+type RatingBucket struct {
+	Rating float64 `json:"rating"`
+	Count  int     `json:"count"`
+}
+
+// RatingHistogram counts a member's ratings, from half a star up to 5 stars
+type RatingHistogram []*RatingBucket
+
+// UserRatings are all of the films a member has rated
+type UserRatings struct {
+	Films     []*Film         `json:"films"`
+	Histogram RatingHistogram `json:"histogram"`
+}
+
+// Ratings returns every film a user has rated, with the rating in
+// Film.UserRating, along with a histogram of the ratings. The films are not
+// enhanced, use FilmService.EnhanceFilmList for the details
+func (u *UserServiceOp) Ratings(ctx context.Context, userID string) (*UserRatings, error) {
+	// Each rating has its own set of pages, so fetch them side by side
+	byRating := make([][]*Film, len(RatingSteps))
+	errs := make([]error, len(RatingSteps))
+	var wg sync.WaitGroup
+	wg.Add(len(RatingSteps))
+	for i, rating := range RatingSteps {
+		go func(i int, rating float64) {
+			defer wg.Done()
+			byRating[i], errs[i] = u.ratedFilms(ctx, userID, rating)
+		}(i, rating)
+	}
+	wg.Wait()
+
+	ratings := &UserRatings{
+		Films:     []*Film{},
+		Histogram: RatingHistogram{},
+	}
+	for i, rating := range RatingSteps {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		ratings.Films = append(ratings.Films, byRating[i]...)
+		ratings.Histogram = append(ratings.Histogram, &RatingBucket{Rating: rating, Count: len(byRating[i])})
+	}
+	return ratings, nil
+}
+
+// ratedFilms walks the pages of films a user gave a single rating
+func (u *UserServiceOp) ratedFilms(ctx context.Context, userID string, rating float64) ([]*Film, error) {
+	var films []*Film
+	for page := 1; page <= maxPages; page++ {
+		partialFilms, pagination, err := u.client.Film.ExtractFilmsWithPath(ctx, fmt.Sprintf("%s/%s/films/rated/%s/page/%d/", u.client.BaseURL, userID, ratingPathSegment(rating), page))
+		if err != nil {
+			return nil, err
+		}
+		for _, film := range partialFilms {
+			if film.UserRating == 0 {
+				film.UserRating = rating
+			}
+		}
+		films = append(films, partialFilms...)
+		if pagination.IsLast {
+			return films, nil
+		}
+	}
+	log.WithFields(log.Fields{
+		"user":   userID,
+		"rating": rating,
+	}).Warn("Stopping ratings at the page limit")
+	return films, nil
+}
+
+// ratingPathSegment formats a rating the way letterboxd does in URLs, like
+// '½', '3' or '3½'
+func ratingPathSegment(rating float64) string {
+	whole := int(rating)
+	if rating == float64(whole) {
+		return strconv.Itoa(whole)
+	}
+	if whole == 0 {
+		return "½"
+	}
+	return fmt.Sprintf("%d½", whole)
+}
+
+// starsFromRatedClass converts a rating span, like <span class="rating
+// rated-7">, in to stars. Letterboxd counts half stars, so rated-7 is 3.5 stars
+func starsFromRatedClass(s *goquery.Selection) float64 {
+	for _, class := range strings.Fields(s.AttrOr("class", "")) {
+		if strings.HasPrefix(class, "rated-") {
+			if n, err := strconv.Atoi(strings.TrimPrefix(class, "rated-")); err == nil {
+				return float64(n) / 2
+			}
+		}
+	}
+	return 0
+}
+
+// extractRatingHistogram reads the ratings histogram shown on a profile. The
+// bars run from half a star to 5 stars, and the count is in the title, like
+// '27 half-★ ratings (2%)'. Profiles without any ratings have no histogram,
+// so every count is zero
+func extractRatingHistogram(doc *goquery.Document) RatingHistogram {
+	histogram := RatingHistogram{}
+	bars := doc.Find("div.rating-histogram li.rating-histogram-bar")
+	if bars.Length() != len(RatingSteps) {
+		for _, rating := range RatingSteps {
+			histogram = append(histogram, &RatingBucket{Rating: rating})
+		}
+		return histogram
+	}
+	bars.Each(func(i int, s *goquery.Selection) {
+		bucket := &RatingBucket{Rating: RatingSteps[i]}
+		// The count and stars are split by a non-breaking space, which Fields splits on
+		title := s.Find("a").AttrOr("title", "")
+		if fields := strings.Fields(title); len(fields) > 0 {
+			bucket.Count, _ = strconv.Atoi(strings.ReplaceAll(fields[0], ",", ""))
+		}
+		histogram = append(histogram, bucket)
+	})
+	return histogram
+}
@@ -0,0 +1,78 @@
+package letterboxd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatingPathSegment(t *testing.T) {
+	require.Equal(t, "½", ratingPathSegment(0.5))
+	require.Equal(t, "1", ratingPathSegment(1))
+	require.Equal(t, "3½", ratingPathSegment(3.5))
+	require.Equal(t, "5", ratingPathSegment(5))
+}
+
+func TestExtractRatingHistogram(t *testing.T) {
+	f, err := os.Open("testdata/user/user.html")
+	defer f.Close()
+	require.NoError(t, err)
+	doc, err := goquery.NewDocumentFromReader(f)
+	require.NoError(t, err)
+	histogram := extractRatingHistogram(doc)
+	require.Equal(t, len(RatingSteps), len(histogram))
+	require.Equal(t, &RatingBucket{Rating: 0.5, Count: 27}, histogram[0])
+	require.Equal(t, &RatingBucket{Rating: 3, Count: 335}, histogram[5])
+	require.Equal(t, &RatingBucket{Rating: 5, Count: 59}, histogram[9])
+
+	// A profile with no ratings still gets every bar, at zero
+	f, err = os.Open("testdata/user/rated-empty.html")
+	defer f.Close()
+	require.NoError(t, err)
+	doc, err = goquery.NewDocumentFromReader(f)
+	require.NoError(t, err)
+	histogram = extractRatingHistogram(doc)
+	require.Equal(t, len(RatingSteps), len(histogram))
+	for i, bucket := range histogram {
+		require.Equal(t, &RatingBucket{Rating: RatingSteps[i]}, bucket)
+	}
+}
+
+func TestRatings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fixture := "testdata/user/rated-empty.html"
+		if strings.HasPrefix(r.URL.Path, "/someguy/films/rated/5/") {
+			fixture = "testdata/user/watched-films-single.html"
+		} else if !strings.HasPrefix(r.URL.Path, "/someguy/films/rated/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open(fixture)
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	ratings, err := client.User.Ratings(context.Background(), "someguy")
+	require.NoError(t, err)
+	require.Equal(t, 34, len(ratings.Films))
+	require.Equal(t, len(RatingSteps), len(ratings.Histogram))
+	require.Equal(t, &RatingBucket{Rating: 5, Count: 34}, ratings.Histogram[9])
+	require.Equal(t, 0, ratings.Histogram[0].Count)
+	for _, film := range ratings.Films {
+		require.NotZero(t, film.UserRating, film.Slug)
+	}
+
+	_, err = client.User.Ratings(context.Background(), "nobody")
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,152 @@
+package letterboxd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+type ReviewService interface {
+	ByUser(context.Context, string, *ReviewOpt) ([]*Review, *Pagination, error)
+	ByFilm(context.Context, string, *ReviewOpt) ([]*Review, *Pagination, error)
+}
+
+type ReviewServiceOp struct {
+	client *ScrapeClient
+}
+
+// Review is a member's written review of a film
+type Review struct {
+	Film       *Film     `json:"film"`
+	Author     string    `json:"author"`                // Username of the reviewer
+	AuthorName string    `json:"author_name,omitempty"` // Display name of the reviewer
+	Date       time.Time `json:"date"`
+	Rating     float64   `json:"rating,omitempty"` // Stars, from 0.5 to 5. Zero if not rated
+	Spoilers   bool      `json:"spoilers"`
+	Likes      int       `json:"likes"`
+	Comments   int       `json:"comments"`
+	BodyHTML   string    `json:"body_html"`
+	BodyText   string    `json:"body_text"`
+	Permalink  string    `json:"permalink"` // Path to the review, like '/someguy/film/the-thing/'
+}
+
+// ReviewOpt is the options for fetching reviews
+type ReviewOpt struct {
+	FirstPage int // First page to fetch. Defaults to 1
+	LastPage  int // Last page to fetch. Defaults to FirstPage. Use -1 to fetch all pages
+}
+
+func (o *ReviewOpt) Validate() error {
+	return validatePages(o.FirstPage, o.LastPage)
+}
+
+// ByUser returns the reviews written by a user, newest first. The Pagination
+// is for the last page fetched
+func (r *ReviewServiceOp) ByUser(ctx context.Context, userID string, opt *ReviewOpt) ([]*Review, *Pagination, error) {
+	return r.reviewPages(ctx, opt, func(page int) string {
+		return fmt.Sprintf("%s/%s/films/reviews/page/%d/", r.client.BaseURL, userID, page)
+	}, nil)
+}
+
+// ByFilm returns the reviews of a film. The Pagination is for the last page
+// fetched
+func (r *ReviewServiceOp) ByFilm(ctx context.Context, slug string, opt *ReviewOpt) ([]*Review, *Pagination, error) {
+	// Film review pages don't repeat the film on each review
+	film := &Film{
+		Slug:   slug,
+		Target: fmt.Sprintf("/film/%s/", slug),
+	}
+	return r.reviewPages(ctx, opt, func(page int) string {
+		return fmt.Sprintf("%s/film/%s/reviews/page/%d/", r.client.BaseURL, slug, page)
+	}, film)
+}
+
+func (r *ReviewServiceOp) reviewPages(ctx context.Context, opt *ReviewOpt, pageURL func(int) string, film *Film) ([]*Review, *Pagination, error) {
+	if opt == nil {
+		opt = &ReviewOpt{}
+	}
+	if err := opt.Validate(); err != nil {
+		return nil, nil, err
+	}
+	startPage, stopPage, err := normalizeStartStop(opt.FirstPage, opt.LastPage)
+	if err != nil {
+		return nil, nil, err
+	}
+	reviews := []*Review{}
+	var pagination Pagination
+	for page := startPage; page < startPage+maxPages; page++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", pageURL(page), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		items, _, err := r.client.sendRequest(req, extractReviews)
+		if err != nil {
+			return nil, nil, err
+		}
+		partialReviews := items.Data.([]*Review)
+		if film != nil {
+			for _, review := range partialReviews {
+				review.Film = film
+			}
+		}
+		reviews = append(reviews, partialReviews...)
+		pagination = items.Pagintion
+		if pagination.IsLast || (stopPage >= 0 && page >= stopPage) {
+			break
+		}
+	}
+	return reviews, &pagination, nil
+}
+
+func extractReviews(r io.Reader) (interface{}, *Pagination, error) {
+	var pageBuf bytes.Buffer
+	tee := io.TeeReader(r, &pageBuf)
+	doc, err := goquery.NewDocumentFromReader(tee)
+	if err != nil {
+		return nil, nil, err
+	}
+	reviews := []*Review{}
+	doc.Find("li.film-detail").Each(func(i int, s *goquery.Selection) {
+		review := &Review{}
+		if poster := s.Find("div.film-poster").First(); poster.Length() > 0 {
+			review.Film = &Film{
+				ID:     poster.AttrOr("data-film-id", ""),
+				Slug:   normalizeSlug(poster.AttrOr("data-film-slug", "")),
+				Target: poster.AttrOr("data-target-link", ""),
+				Title:  poster.Find("img.image").AttrOr("alt", ""),
+			}
+		}
+		contextLink := s.Find("a.context").First()
+		review.Permalink = contextLink.AttrOr("href", "")
+		review.Author = strings.Split(strings.Trim(review.Permalink, "/"), "/")[0]
+		review.AuthorName = strings.TrimSpace(contextLink.Find("strong.name").Text())
+		review.Rating = starsFromRatedClass(s.Find("span.rating").First())
+		if d, err := time.Parse("02 Jan 2006", strings.TrimSpace(s.Find("span.date a").Last().Text())); err == nil {
+			review.Date = d
+		}
+
+		body := s.Find("div.body-text").First()
+		review.Spoilers = body.HasClass("contains-spoilers")
+		if review.Spoilers {
+			// Drop the warning letterboxd puts at the top of the review
+			body.Find("p").FilterFunction(func(i int, s *goquery.Selection) bool {
+				return strings.Contains(s.Text(), "This review may contain spoilers")
+			}).Remove()
+		}
+		review.BodyHTML, _ = body.Html()
+		review.BodyHTML = strings.TrimSpace(review.BodyHTML)
+		review.BodyText = paragraphsText(body)
+
+		review.Likes = parseCount(s.Find("p.like-link-target").AttrOr("data-count", "0"))
+		review.Comments = parseCount(s.Find("a.comment-count").Text())
+
+		reviews = append(reviews, review)
+	})
+	return reviews, paginationOrSinglePage(&pageBuf), nil
+}
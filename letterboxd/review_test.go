@@ -0,0 +1,105 @@
+package letterboxd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractReviews(t *testing.T) {
+	f, err := os.Open("testdata/review/user-1.html")
+	defer f.Close()
+	require.NoError(t, err)
+	items, pagination, err := extractReviews(f)
+	require.NoError(t, err)
+	require.Equal(t, 2, pagination.TotalPages)
+	reviews := items.([]*Review)
+	require.Equal(t, 2, len(reviews))
+
+	first := reviews[0]
+	require.Equal(t, "sweet-sweetbacks-baadasssss-song", first.Film.Slug)
+	require.Equal(t, "someguy", first.Author)
+	require.Equal(t, "Some Guy", first.AuthorName)
+	require.Equal(t, 3.5, first.Rating)
+	require.Equal(t, time.Date(2022, 4, 18, 0, 0, 0, 0, time.UTC), first.Date)
+	require.False(t, first.Spoilers)
+	require.Equal(t, 42, first.Likes)
+	require.Equal(t, 3, first.Comments)
+	require.Equal(t, "Loud, messy and completely alive.\n\nNothing else looks like it.", first.BodyText)
+	require.Contains(t, first.BodyHTML, "<em>completely</em>")
+	require.Equal(t, "/someguy/film/sweet-sweetbacks-baadasssss-song/", first.Permalink)
+
+	second := reviews[1]
+	require.True(t, second.Spoilers)
+	require.Equal(t, "The blood test scene still gets me.", second.BodyText)
+	require.Equal(t, 1204, second.Likes)
+	require.Equal(t, 0, second.Comments)
+}
+
+func newReviewServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fixture string
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/someguy/films/reviews/page/"):
+			fixture = fmt.Sprintf("testdata/review/user-%v.html", strings.Split(r.URL.Path, "/")[5])
+		case strings.HasPrefix(r.URL.Path, "/film/sweet-sweetbacks-baadasssss-song/reviews/page/"):
+			fixture = "testdata/review/film.html"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open(fixture)
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+}
+
+func TestReviewsByUser(t *testing.T) {
+	srv := newReviewServer(t)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	reviews, pagination, err := client.Review.ByUser(context.Background(), "someguy", nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(reviews))
+	require.False(t, pagination.IsLast)
+
+	reviews, pagination, err = client.Review.ByUser(context.Background(), "someguy", &ReviewOpt{LastPage: -1})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(reviews))
+	require.True(t, pagination.IsLast)
+	require.Equal(t, "gremlins", reviews[2].Film.Slug)
+	require.Zero(t, reviews[2].Rating)
+
+	_, _, err = client.Review.ByUser(context.Background(), "someguy", &ReviewOpt{FirstPage: -1})
+	require.Error(t, err)
+}
+
+func TestReviewsByFilm(t *testing.T) {
+	srv := newReviewServer(t)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	reviews, _, err := client.Review.ByFilm(context.Background(), "sweet-sweetbacks-baadasssss-song", nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(reviews))
+	for _, review := range reviews {
+		require.Equal(t, "sweet-sweetbacks-baadasssss-song", review.Film.Slug)
+	}
+	require.Equal(t, "otherguy", reviews[1].Author)
+	require.True(t, reviews[1].Spoilers)
+	require.Equal(t, time.Date(2021, 7, 4, 0, 0, 0, 0, time.UTC), reviews[1].Date)
+
+	_, _, err = client.Review.ByFilm(context.Background(), "never-made", nil)
+	require.ErrorIs(t, err, ErrNotFound)
+}
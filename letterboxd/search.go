@@ -0,0 +1,121 @@
+package letterboxd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// SearchResult is a film that matched a search, and where it ranked
+type SearchResult struct {
+	Rank int   `json:"rank"` // 1 based position in letterboxd's results
+	Film *Film `json:"film"`
+}
+
+// searchPageSize is how many results letterboxd shows on each search page
+const searchPageSize = 20
+
+// SearchOpt is the options for a film search
+type SearchOpt struct {
+	Year      int // Only return films released this year. Results keep their original Rank
+	FirstPage int // First page to fetch. Defaults to 1
+	LastPage  int // Last page to fetch. Defaults to FirstPage. Use -1 to fetch all pages
+}
+
+func (o *SearchOpt) Validate() error {
+	return validatePages(o.FirstPage, o.LastPage)
+}
+
+// Search looks films up by title using letterboxd's search pages. Results are
+// in the order letterboxd ranks them, best match first. The Pagination is for
+// the last page fetched
+func (f *FilmServiceOp) Search(ctx context.Context, query string, opt *SearchOpt) ([]*SearchResult, *Pagination, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil, errors.New("query is required")
+	}
+	if opt == nil {
+		opt = &SearchOpt{}
+	}
+	if err := opt.Validate(); err != nil {
+		return nil, nil, err
+	}
+	startPage, stopPage, err := normalizeStartStop(opt.FirstPage, opt.LastPage)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Letterboxd escapes searches like a query string, with '+' for spaces,
+	// so a '+' in the title has to be '%2B'
+	q := url.QueryEscape(query)
+	results := []*SearchResult{}
+	var pagination Pagination
+	// Ranks count from the top of letterboxd's results, even when starting
+	// part way through them
+	rank := (startPage - 1) * searchPageSize
+	for page := startPage; page < startPage+maxPages; page++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/search/films/%s/page/%d/", f.client.BaseURL, q, page), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		items, _, err := f.client.sendRequest(req, extractSearchResults)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, result := range items.Data.([]*SearchResult) {
+			rank++
+			result.Rank = rank
+			if opt.Year != 0 && result.Film.Year != opt.Year {
+				continue
+			}
+			results = append(results, result)
+		}
+		pagination = items.Pagintion
+		if pagination.IsLast || (stopPage >= 0 && page >= stopPage) {
+			break
+		}
+	}
+	return results, &pagination, nil
+}
+
+func extractSearchResults(r io.Reader) (interface{}, *Pagination, error) {
+	var pageBuf bytes.Buffer
+	tee := io.TeeReader(r, &pageBuf)
+	doc, err := goquery.NewDocumentFromReader(tee)
+	if err != nil {
+		return nil, nil, err
+	}
+	results := []*SearchResult{}
+	doc.Find("ul.results li").Each(func(i int, s *goquery.Selection) {
+		poster := s.Find("div.film-poster").First()
+		if poster.Length() == 0 {
+			return
+		}
+		film := &Film{
+			ID:     poster.AttrOr("data-film-id", ""),
+			Slug:   normalizeSlug(poster.AttrOr("data-film-slug", "")),
+			Target: poster.AttrOr("data-target-link", ""),
+			Title:  strings.TrimSpace(s.Find("span.film-title-wrapper > a").First().Text()),
+		}
+		if film.Title == "" {
+			film.Title = poster.Find("img.image").AttrOr("alt", "")
+		}
+		if year, err := strconv.Atoi(strings.TrimSpace(s.Find("span.film-title-wrapper small.metadata a").First().Text())); err == nil {
+			film.Year = year
+		}
+		s.Find("p.film-metadata a.text-slug").Each(func(i int, s *goquery.Selection) {
+			if profession, _ := splitPersonPath(s.AttrOr("href", "")); profession == "director" {
+				film.Directors = append(film.Directors, strings.TrimSpace(s.Text()))
+			}
+		})
+		results = append(results, &SearchResult{Film: film})
+	})
+	return results, paginationOrSinglePage(&pageBuf), nil
+}
@@ -0,0 +1,96 @@
+package letterboxd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractSearchResults(t *testing.T) {
+	f, err := os.Open("testdata/search/the-thing-1.html")
+	require.NoError(t, err)
+	defer f.Close()
+	items, pagination, err := extractSearchResults(f)
+	require.NoError(t, err)
+	require.Equal(t, 2, pagination.TotalPages)
+	results := items.([]*SearchResult)
+	require.Equal(t, 3, len(results))
+	require.Equal(t, &Film{
+		ID:        "51568",
+		Title:     "The Thing",
+		Slug:      "the-thing",
+		Target:    "/film/the-thing/",
+		Year:      1982,
+		Directors: []string{"John Carpenter"},
+	}, results[0].Film)
+	require.Equal(t, []string{"Christian Nyby", "Howard Hawks"}, results[1].Film.Directors)
+}
+
+func TestSearch(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		var fixture string
+		switch r.URL.EscapedPath() {
+		case "/search/films/the+thing/page/1/", "/search/films/C%2B%2B/page/1/":
+			fixture = "testdata/search/the-thing-1.html"
+		case "/search/films/the+thing/page/2/":
+			fixture = "testdata/search/the-thing-2.html"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open(fixture)
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	results, pagination, err := client.Film.Search(context.Background(), "the thing", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/search/films/the+thing/page/1/"}, paths)
+	require.Equal(t, 3, len(results))
+	require.False(t, pagination.IsLast)
+
+	results, pagination, err = client.Film.Search(context.Background(), " the thing ", &SearchOpt{LastPage: -1})
+	require.NoError(t, err)
+	require.Equal(t, 5, len(results))
+	require.True(t, pagination.IsLast)
+	require.Equal(t, 5, results[4].Rank)
+	require.Equal(t, "The Thing", results[4].Film.Title)
+	require.Zero(t, results[4].Film.Year)
+	require.Empty(t, results[4].Film.Directors)
+
+	// Filtered results keep the rank letterboxd gave them
+	results, _, err = client.Film.Search(context.Background(), "the thing", &SearchOpt{Year: 2011, LastPage: -1})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(results))
+	require.Equal(t, 3, results[0].Rank)
+	require.Equal(t, "the-thing-2011", results[0].Film.Slug)
+
+	// Starting on a later page ranks from where that page sits
+	results, _, err = client.Film.Search(context.Background(), "the thing", &SearchOpt{FirstPage: 2})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(results))
+	require.Equal(t, searchPageSize+1, results[0].Rank)
+	require.Equal(t, searchPageSize+2, results[1].Rank)
+
+	// A '+' is part of the title, not a space
+	paths = nil
+	_, _, err = client.Film.Search(context.Background(), "C++", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/search/films/C%2B%2B/page/1/"}, paths)
+
+	_, _, err = client.Film.Search(context.Background(), "  ", nil)
+	require.Error(t, err)
+	_, _, err = client.Film.Search(context.Background(), "the thing", &SearchOpt{FirstPage: -1})
+	require.Error(t, err)
+}
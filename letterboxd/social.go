@@ -0,0 +1,191 @@
+package letterboxd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/apex/log"
+)
+
+const (
+	// DefaultSocialGraphDepth is how many hops out from the root user a social
+	// graph goes, unless told otherwise
+	DefaultSocialGraphDepth = 2
+	// MaxSocialGraphDepth keeps graphs from wandering across all of letterboxd
+	MaxSocialGraphDepth = 4
+	// DefaultSocialGraphMaxUsers is a sane cap on the users in a graph for
+	// callers that take requests from anyone, like the API. Popular users
+	// follow thousands of people, so even two hops can be a lot of requests
+	DefaultSocialGraphMaxUsers = 500
+)
+
+// Following returns the users that a user follows. The users are stubs, with
+// just the username, display name and watched film count
+func (u *UserServiceOp) Following(ctx context.Context, userID string) ([]*User, error) {
+	return u.people(ctx, userID, "following")
+}
+
+// Followers returns the users that follow a user. The users are stubs, with
+// just the username, display name and watched film count
+func (u *UserServiceOp) Followers(ctx context.Context, userID string) ([]*User, error) {
+	return u.people(ctx, userID, "followers")
+}
+
+func (u *UserServiceOp) people(ctx context.Context, userID, kind string) ([]*User, error) {
+	users := []*User{}
+	for page := 1; page <= maxPages; page++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s/%s/page/%d/", u.client.BaseURL, userID, kind, page), nil)
+		if err != nil {
+			return nil, err
+		}
+		items, _, err := u.client.sendRequest(req, extractPeople)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, items.Data.([]*User)...)
+		if items.Pagintion.IsLast {
+			return users, nil
+		}
+	}
+	log.WithFields(log.Fields{
+		"user": userID,
+		"kind": kind,
+	}).Warn("Stopping at the page limit")
+	return users, nil
+}
+
+func extractPeople(r io.Reader) (interface{}, *Pagination, error) {
+	var pageBuf bytes.Buffer
+	tee := io.TeeReader(r, &pageBuf)
+	doc, err := goquery.NewDocumentFromReader(tee)
+	if err != nil {
+		return nil, nil, err
+	}
+	users := []*User{}
+	doc.Find("table.person-table tr").Each(func(i int, s *goquery.Selection) {
+		name := s.Find("div.person-summary a.name").First()
+		username := strings.Trim(name.AttrOr("href", ""), "/")
+		if username == "" {
+			return
+		}
+		user := &User{
+			Username:    username,
+			DisplayName: strings.TrimSpace(name.Text()),
+		}
+		count := strings.ReplaceAll(strings.TrimSpace(s.Find("td.col-watched a").Text()), ",", "")
+		user.WatchedFilmCount, _ = strconv.Atoi(count)
+		users = append(users, user)
+	})
+	return users, paginationOrSinglePage(&pageBuf), nil
+}
+
+// SocialGraphOpt is the options for building a social graph
+type SocialGraphOpt struct {
+	Depth     int  // Hops out from the root user. Defaults to DefaultSocialGraphDepth
+	Followers bool // Walk who follows each user, instead of who they follow
+	MaxUsers  int  // Stop adding users once the graph has this many. 0 means no limit
+}
+
+// SocialGraph is the network of users within a number of hops of a root user
+type SocialGraph struct {
+	Root      string              `json:"root"`
+	Depth     map[string]int      `json:"depth"`               // Hops from Root, for every user in the graph
+	Edges     map[string][]string `json:"edges"`               // The users each user links to. Users at the edge of the graph are not expanded
+	Truncated bool                `json:"truncated,omitempty"` // If users were left out to stay under MaxUsers
+}
+
+// AtDepth returns the users that are exactly depth hops from the root, sorted
+// by username. AtDepth(2) is friends of friends
+func (g *SocialGraph) AtDepth(depth int) []string {
+	users := []string{}
+	for user, d := range g.Depth {
+		if d == depth {
+			users = append(users, user)
+		}
+	}
+	sort.Strings(users)
+	return users
+}
+
+// SocialGraph walks out from a user through who they follow (or their
+// followers), breadth first. Users already seen are not visited again, so
+// cycles in the graph are fine. Only users in the graph are visited, so
+// MaxUsers also caps the requests made
+func (u *UserServiceOp) SocialGraph(ctx context.Context, userID string, opt *SocialGraphOpt) (*SocialGraph, error) {
+	if opt == nil {
+		opt = &SocialGraphOpt{}
+	}
+	depth := opt.Depth
+	if depth == 0 {
+		depth = DefaultSocialGraphDepth
+	}
+	if depth < 0 || depth > MaxSocialGraphDepth {
+		return nil, fmt.Errorf("Depth must be between 1 and %d", MaxSocialGraphDepth)
+	}
+	if opt.MaxUsers < 0 {
+		return nil, errors.New("MaxUsers must be positive")
+	}
+	if userID == "" {
+		return nil, errors.New("user is required")
+	}
+	walk := u.Following
+	if opt.Followers {
+		walk = u.Followers
+	}
+
+	g := &SocialGraph{
+		Root:  userID,
+		Depth: map[string]int{userID: 0},
+		Edges: map[string][]string{},
+	}
+	frontier := []string{userID}
+	for hop := 1; hop <= depth && len(frontier) > 0; hop++ {
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		var walkErr error
+		next := []string{}
+		wg.Add(len(frontier))
+		for _, name := range frontier {
+			go func(name string) {
+				defer wg.Done()
+				people, err := walk(ctx, name)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					if walkErr == nil && !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrPrivateProfile) {
+						walkErr = err
+					}
+					return
+				}
+				links := make([]string, 0, len(people))
+				for _, p := range people {
+					links = append(links, p.Username)
+					if _, seen := g.Depth[p.Username]; !seen {
+						if opt.MaxUsers > 0 && len(g.Depth) >= opt.MaxUsers {
+							g.Truncated = true
+							continue
+						}
+						g.Depth[p.Username] = hop
+						next = append(next, p.Username)
+					}
+				}
+				g.Edges[name] = links
+			}(name)
+		}
+		wg.Wait()
+		if walkErr != nil {
+			return nil, walkErr
+		}
+		frontier = next
+	}
+	return g, nil
+}
@@ -0,0 +1,118 @@
+package letterboxd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractPeople(t *testing.T) {
+	f, err := os.Open("testdata/user/following/1.html")
+	defer f.Close()
+	require.NoError(t, err)
+	items, pagination, err := extractPeople(f)
+	require.NoError(t, err)
+	require.False(t, pagination.IsLast)
+	users := items.([]*User)
+	require.Equal(t, 3, len(users))
+	require.Equal(t, &User{Username: "dankmccoy", DisplayName: "Dan McCoy", WatchedFilmCount: 1398}, users[0])
+	require.Equal(t, 2045, users[2].WatchedFilmCount)
+}
+
+func TestFollowingAndFollowers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fixture string
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/mondodrew/following/page/"):
+			fixture = fmt.Sprintf("testdata/user/following/%v.html", strings.Split(r.URL.Path, "/")[4])
+		case strings.HasPrefix(r.URL.Path, "/mondodrew/followers/page/"):
+			fixture = "testdata/user/followers.html"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open(fixture)
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	following, err := client.User.Following(context.Background(), "mondodrew")
+	require.NoError(t, err)
+	require.Equal(t, 4, len(following))
+	require.Equal(t, "thirdguy", following[3].Username)
+
+	followers, err := client.User.Followers(context.Background(), "mondodrew")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(followers))
+	require.Equal(t, "someguy", followers[0].Username)
+}
+
+// personTable renders just enough of a following page to list users
+func personTable(users ...string) string {
+	var b strings.Builder
+	b.WriteString(`<table class="person-table"><tbody>`)
+	for _, u := range users {
+		fmt.Fprintf(&b, `<tr><td class="table-person"><div class="person-summary"><h3 class="title-3"><a href="/%s/" class="name">%s</a></h3></div></td></tr>`, u, u)
+	}
+	b.WriteString(`</tbody></table>`)
+	return b.String()
+}
+
+func TestSocialGraph(t *testing.T) {
+	// a follows b and c, b follows a back and d, c follows d, d follows a.
+	// e is only reachable in 3 hops
+	follows := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"a", "d"},
+		"c": {"d"},
+		"d": {"a", "e"},
+		"e": {},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		users, ok := follows[parts[0]]
+		if !ok || parts[1] != "following" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, personTable(users...))
+	}))
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	g, err := client.User.SocialGraph(context.Background(), "a", nil)
+	require.NoError(t, err)
+	require.Equal(t, "a", g.Root)
+	require.Equal(t, []string{"a"}, g.AtDepth(0))
+	require.Equal(t, []string{"b", "c"}, g.AtDepth(1))
+	require.Equal(t, []string{"d"}, g.AtDepth(2))
+	require.Empty(t, g.AtDepth(3))
+	require.Equal(t, []string{"a", "d"}, g.Edges["b"])
+	// d is at the edge, so it is not expanded
+	require.NotContains(t, g.Edges, "d")
+
+	g, err = client.User.SocialGraph(context.Background(), "a", &SocialGraphOpt{Depth: 4})
+	require.NoError(t, err)
+	require.Equal(t, []string{"e"}, g.AtDepth(3))
+	require.Equal(t, 5, len(g.Depth))
+
+	g, err = client.User.SocialGraph(context.Background(), "a", &SocialGraphOpt{Depth: 4, MaxUsers: 3})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(g.Depth))
+	require.True(t, g.Truncated)
+	require.Empty(t, g.AtDepth(2))
+
+	_, err = client.User.SocialGraph(context.Background(), "a", &SocialGraphOpt{Depth: MaxSocialGraphDepth + 1})
+	require.Error(t, err)
+}
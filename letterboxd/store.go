@@ -0,0 +1,145 @@
+package letterboxd
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/apex/log"
+	bolt "go.etcd.io/bbolt"
+)
+
+// DefaultFilmStoreMaxAge is how long a stored film is used before its pages
+// are fetched again, unless told otherwise
+const DefaultFilmStoreMaxAge = 30 * 24 * time.Hour
+
+var (
+	filmsBucket  = []byte("films")    // Slug to storedFilm
+	filmIDBucket = []byte("film_ids") // Film ID to slug
+)
+
+// FilmStore keeps enhanced films between runs, so EnhanceFilmList doesn't have
+// to fetch the same film pages over and over
+type FilmStore interface {
+	// GetFilm returns the film stored under a slug or a film ID, along with
+	// when it was stored. ok is false if the film isn't in the store
+	GetFilm(key string) (film *Film, stored time.Time, ok bool, err error)
+	PutFilm(film *Film) error
+}
+
+type storedFilm struct {
+	Stored time.Time `json:"stored"`
+	Film   *Film     `json:"film"`
+}
+
+// BoltFilmStore is a FilmStore kept in a bbolt database file
+type BoltFilmStore struct {
+	db *bolt.DB
+}
+
+// NewBoltFilmStore opens the store at path, creating it if needed. Only one
+// process can have the store open at a time
+func NewBoltFilmStore(path string) (*BoltFilmStore, error) {
+	if path == "" {
+		return nil, errors.New("film store path is required")
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{filmsBucket, filmIDBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltFilmStore{db: db}, nil
+}
+
+func (b *BoltFilmStore) GetFilm(key string) (*Film, time.Time, bool, error) {
+	var entry *storedFilm
+	err := b.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(filmsBucket).Get([]byte(key))
+		if v == nil {
+			slug := tx.Bucket(filmIDBucket).Get([]byte(key))
+			if slug == nil {
+				return nil
+			}
+			v = tx.Bucket(filmsBucket).Get(slug)
+			if v == nil {
+				return nil
+			}
+		}
+		entry = &storedFilm{}
+		return json.Unmarshal(v, entry)
+	})
+	if err != nil || entry == nil {
+		return nil, time.Time{}, false, err
+	}
+	return entry.Film, entry.Stored, true, nil
+}
+
+func (b *BoltFilmStore) PutFilm(film *Film) error {
+	if film.Slug == "" {
+		return errors.New("can't store a film without a slug")
+	}
+	// Ratings, likes and sources belong to whoever's page the film came from,
+	// not the film
+	f := *film
+	f.UserRating = 0
+	f.UserLiked = false
+	f.Sources = nil
+	v, err := json.Marshal(storedFilm{Stored: time.Now(), Film: &f})
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(filmsBucket).Put([]byte(f.Slug), v); err != nil {
+			return err
+		}
+		if f.ID == "" {
+			return nil
+		}
+		return tx.Bucket(filmIDBucket).Put([]byte(f.ID), []byte(f.Slug))
+	})
+}
+
+// Close closes the database file
+func (b *BoltFilmStore) Close() error {
+	return b.db.Close()
+}
+
+// storedFilmDetails fills in film from the client's film store, if it has a
+// copy that isn't too old. Returns false if the film still needs to be fetched
+func (c *ScrapeClient) storedFilmDetails(film *Film) bool {
+	if c.filmStore == nil {
+		return false
+	}
+	for _, key := range []string{film.Slug, film.ID} {
+		if key == "" {
+			continue
+		}
+		stored, at, ok, err := c.filmStore.GetFilm(key)
+		if err != nil {
+			log.WithError(err).WithField("key", key).Warn("Failed to read film store")
+			return false
+		}
+		if !ok {
+			continue
+		}
+		if time.Since(at) > c.filmStoreMaxAge {
+			return false
+		}
+		rating, liked, sources := film.UserRating, film.UserLiked, film.Sources
+		*film = *stored
+		film.UserRating, film.UserLiked, film.Sources = rating, liked, sources
+		return true
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package letterboxd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoltFilmStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "films.db")
+	store, err := NewBoltFilmStore(path)
+	require.NoError(t, err)
+
+	_, _, ok, err := store.GetFilm("the-thing")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, store.PutFilm(&Film{
+		ID:         "51568",
+		Slug:       "the-thing",
+		Title:      "The Thing",
+		Year:       1982,
+		UserRating: 5,
+		UserLiked:  true,
+	}))
+	require.Error(t, store.PutFilm(&Film{ID: "1"}))
+
+	film, stored, ok, err := store.GetFilm("the-thing")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.WithinDuration(t, time.Now(), stored, time.Minute)
+	require.Equal(t, "The Thing", film.Title)
+	// Member ratings aren't part of the film
+	require.Zero(t, film.UserRating)
+	require.False(t, film.UserLiked)
+
+	// Films can be found by ID too, and survive a reopen
+	require.NoError(t, store.Close())
+	store, err = NewBoltFilmStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+	film, _, ok, err = store.GetFilm("51568")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "the-thing", film.Slug)
+}
+
+func TestEnhanceFilmListWithStore(t *testing.T) {
+	var filmRequests int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/film/") {
+			atomic.AddInt64(&filmRequests, 1)
+			rp, err := os.Open("testdata/film/sweetback.html")
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	store, err := NewBoltFilmStore(filepath.Join(t.TempDir(), "films.db"))
+	require.NoError(t, err)
+	defer store.Close()
+	newClient := func(maxAge time.Duration) *ScrapeClient {
+		client := NewScrapeClient(nil, &ClientOptions{
+			Rate:            -1,
+			Concurrency:     -1,
+			FilmStore:       store,
+			FilmStoreMaxAge: maxAge,
+		})
+		client.BaseURL = srv.URL
+		return client
+	}
+	previews := func() []*Film {
+		return []*Film{
+			{Slug: "sweet-sweetbacks-baadasssss-song", Target: "/film/sweet-sweetbacks-baadasssss-song/", UserRating: 4},
+		}
+	}
+
+	client := newClient(0)
+	films := previews()
+	require.NoError(t, client.Film.EnhanceFilmList(context.Background(), &films))
+	// The film page, and its themes page
+	require.Equal(t, int64(2), atomic.LoadInt64(&filmRequests))
+	require.NotNil(t, films[0].ExternalIDs)
+
+	// Second time around, the film comes from the store
+	films = previews()
+	require.NoError(t, client.Film.EnhanceFilmList(context.Background(), &films))
+	require.Equal(t, int64(2), atomic.LoadInt64(&filmRequests))
+	require.Equal(t, "tt0067810", films[0].ExternalIDs.IMDB)
+	require.Equal(t, float64(4), films[0].UserRating)
+
+	// Stale films are fetched again
+	client = newClient(time.Nanosecond)
+	films = previews()
+	require.NoError(t, client.Film.EnhanceFilmList(context.Background(), &films))
+	require.Equal(t, int64(4), atomic.LoadInt64(&filmRequests))
+}
@@ -0,0 +1,301 @@
+package letterboxd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/apex/log"
+)
+
+// SyncState is what a watched sync remembers about a user between runs
+type SyncState struct {
+	User   string    `json:"user"`
+	Synced time.Time `json:"synced"`
+	Films  []*Film   `json:"films"` // Newest first. Only the ID, slug, target and title are kept
+}
+
+// WatchedDelta is the change in a user's watched films since the last sync
+type WatchedDelta struct {
+	Added   []*Film    `json:"added"`      // Newest first, enhanced like EnhanceFilmList
+	Removed []*Film    `json:"removed"`    // As remembered in the old state
+	State   *SyncState `json:"-" yaml:"-"` // Save this for the next sync
+}
+
+// SyncStateStore keeps sync states between runs
+type SyncStateStore interface {
+	// Load returns the state for a user, or nil if there isn't one yet
+	Load(user string) (*SyncState, error)
+	Save(state *SyncState) error
+}
+
+// SyncWatched finds the films a user has added to, or removed from, their
+// watched films since the state in since was taken. Watched films are walked
+// newest first, stopping at the first page of films that are already known.
+// The whole history is only walked if the profile's film count shows that
+// films were removed. A nil since walks everything, and reports every film as
+// added
+func (u *UserServiceOp) SyncWatched(ctx context.Context, userID string, since *SyncState) (*WatchedDelta, error) {
+	if since != nil && since.User != "" && since.User != userID {
+		return nil, fmt.Errorf("sync state is for %v, not %v", since.User, userID)
+	}
+	known := map[string]*Film{}
+	if since != nil {
+		for _, film := range since.Films {
+			known[film.Slug] = film
+		}
+	}
+	synced := time.Now()
+
+	// New films show up at the front, so stop as soon as a page has films we
+	// already know about
+	var head []*Film
+	pages, err := u.watchedPages(ctx, userID, func(films []*Film) bool {
+		head = append(head, films...)
+		for _, film := range films {
+			if _, ok := known[film.Slug]; ok {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	added := []*Film{}
+	for _, film := range head {
+		if _, ok := known[film.Slug]; !ok {
+			added = append(added, film)
+		}
+	}
+
+	current := head
+	removed := []*Film{}
+	switch {
+	case pages.truncated:
+		current = append(current, missingFilms(since, head)...)
+	case pages.complete:
+		removed = missingFilms(since, head)
+	default:
+		profile, _, err := u.Profile(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if profile.WatchedFilmCount == len(known)+len(added) {
+			// Nothing was removed, so the rest of the history is what we had
+			current = append(current, FilmsNotIn(since.Films, head)...)
+		} else {
+			log.WithFields(log.Fields{
+				"user":     userID,
+				"expected": len(known) + len(added),
+				"count":    profile.WatchedFilmCount,
+			}).Debug("Watched count is off, walking all watched films")
+			current = nil
+			all, err := u.watchedPages(ctx, userID, func(films []*Film) bool {
+				current = append(current, films...)
+				return true
+			})
+			if err != nil {
+				return nil, err
+			}
+			if all.truncated {
+				current = append(current, missingFilms(since, current)...)
+			} else {
+				removed = missingFilms(since, current)
+			}
+		}
+	}
+
+	state := &SyncState{
+		User:   userID,
+		Synced: synced,
+		Films:  syncStateFilms(current),
+	}
+	if err = u.client.Film.EnhanceFilmList(ctx, &added); err != nil {
+		return nil, err
+	}
+	return &WatchedDelta{
+		Added:   added,
+		Removed: removed,
+		State:   state,
+	}, nil
+}
+
+type watchedWalk struct {
+	complete  bool // Every page was walked
+	truncated bool // Gave up at the page limit, so removed films can't be told apart from unwalked ones
+}
+
+// watchedPages walks a user's watched films one page at a time, newest first,
+// until fn returns false or the pages run out
+func (u *UserServiceOp) watchedPages(ctx context.Context, userID string, fn func([]*Film) bool) (*watchedWalk, error) {
+	for page := 1; page <= maxPages; page++ {
+		films, pagination, err := u.client.Film.ExtractFilmsWithPath(ctx, fmt.Sprintf("%s/%s/films/page/%d/", u.client.BaseURL, userID, page))
+		if err != nil {
+			return nil, err
+		}
+		more := fn(films)
+		if pagination.IsLast {
+			return &watchedWalk{complete: true}, nil
+		}
+		if !more {
+			return &watchedWalk{}, nil
+		}
+	}
+	log.WithField("user", userID).Warn("Stopping watched sync at the page limit")
+	return &watchedWalk{truncated: true}, nil
+}
+
+// missingFilms returns the films in the old state that aren't in current
+func missingFilms(since *SyncState, current []*Film) []*Film {
+	if since == nil {
+		return []*Film{}
+	}
+	return FilmsNotIn(since.Films, current)
+}
+
+// FilmsNotIn returns the films in films that aren't in exclude, by slug
+func FilmsNotIn(films, exclude []*Film) []*Film {
+	seen := map[string]bool{}
+	for _, film := range exclude {
+		seen[film.Slug] = true
+	}
+	ret := []*Film{}
+	for _, film := range films {
+		if !seen[film.Slug] {
+			ret = append(ret, film)
+		}
+	}
+	return ret
+}
+
+// syncStateFilms trims films down to what a SyncState keeps
+func syncStateFilms(films []*Film) []*Film {
+	ret := make([]*Film, 0, len(films))
+	for _, film := range films {
+		ret = append(ret, &Film{
+			ID:     film.ID,
+			Slug:   film.Slug,
+			Target: film.Target,
+			Title:  film.Title,
+		})
+	}
+	return ret
+}
+
+// SyncWatchedWithStore syncs a user's watched films against the state in
+// store, saving the new state once the sync is done
+func SyncWatchedWithStore(ctx context.Context, users UserService, store SyncStateStore, userID string) (*WatchedDelta, error) {
+	since, err := store.Load(userID)
+	if err != nil {
+		return nil, err
+	}
+	delta, err := users.SyncWatched(ctx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	if err = store.Save(delta.State); err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
+
+// MemorySyncStateStore keeps sync states in memory, for the life of the process
+type MemorySyncStateStore struct {
+	mu     sync.Mutex
+	states map[string]*SyncState
+}
+
+func NewMemorySyncStateStore() *MemorySyncStateStore {
+	return &MemorySyncStateStore{states: map[string]*SyncState{}}
+}
+
+func (m *MemorySyncStateStore) Load(user string) (*SyncState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.states[user], nil
+}
+
+func (m *MemorySyncStateStore) Save(state *SyncState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[state.User] = state
+	return nil
+}
+
+// FileSyncStateStore keeps each user's sync state as a JSON file in a
+// directory
+type FileSyncStateStore struct {
+	dir string
+}
+
+// NewFileSyncStateStore returns a store backed by dir, creating it if needed
+func NewFileSyncStateStore(dir string) (*FileSyncStateStore, error) {
+	if dir == "" {
+		return nil, errors.New("sync state directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileSyncStateStore{dir: dir}, nil
+}
+
+func (f *FileSyncStateStore) path(user string) string {
+	return filepath.Join(f.dir, fmt.Sprintf("%s.json", user))
+}
+
+func validSyncStateUser(user string) error {
+	if user == "" || filepath.Base(user) != user || user == ".." {
+		return fmt.Errorf("invalid user for sync state: %q", user)
+	}
+	return nil
+}
+
+func (f *FileSyncStateStore) Load(user string) (*SyncState, error) {
+	if err := validSyncStateUser(user); err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(f.path(user))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	state := &SyncState{}
+	if err := json.Unmarshal(b, state); err != nil {
+		return nil, fmt.Errorf("%v: %w", f.path(user), err)
+	}
+	return state, nil
+}
+
+func (f *FileSyncStateStore) Save(state *SyncState) error {
+	if err := validSyncStateUser(state.User); err != nil {
+		return err
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	// Write to a temp file first so a crash never leaves a partial state
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), f.path(state.User))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
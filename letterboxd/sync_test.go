@@ -0,0 +1,143 @@
+package letterboxd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newSyncTestServer serves someguy's paginated watched films, and a profile
+// claiming count films. Requested watched pages are recorded in pages
+func newSyncTestServer(t *testing.T, count int, pages *[]string) *httptest.Server {
+	profile, err := os.ReadFile("testdata/user/user.html")
+	require.NoError(t, err)
+	profile = []byte(strings.ReplaceAll(string(profile), "1,398", fmt.Sprint(count)))
+	sweetback, err := os.ReadFile("testdata/film/sweetback.html")
+	require.NoError(t, err)
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/someguy/films/page/"):
+			pageNo := strings.Split(r.URL.Path, "/")[4]
+			mu.Lock()
+			*pages = append(*pages, pageNo)
+			mu.Unlock()
+			b, err := os.ReadFile(fmt.Sprintf("testdata/user/watched-paginated/%v.html", pageNo))
+			require.NoError(t, err)
+			w.Write(b)
+		case strings.TrimSuffix(r.URL.Path, "/") == "/someguy":
+			w.Write(profile)
+		case strings.HasPrefix(r.URL.Path, "/film/"):
+			w.Write(sweetback)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestSyncWatched(t *testing.T) {
+	var pages []string
+	srv := newSyncTestServer(t, 321, &pages)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	// First sync sees everything as new
+	delta, err := client.User.SyncWatched(context.Background(), "someguy", nil)
+	require.NoError(t, err)
+	require.Len(t, delta.Added, 321)
+	require.Empty(t, delta.Removed)
+	require.Len(t, delta.State.Films, 321)
+	require.Equal(t, "someguy", delta.State.User)
+	require.Equal(t, []string{"1", "2", "3", "4", "5"}, pages)
+	// State only keeps what's needed to recognise a film
+	require.Empty(t, delta.State.Films[0].ExternalIDs)
+
+	// Pretend the three newest films were watched since then
+	since := &SyncState{User: "someguy", Films: delta.State.Films[3:]}
+	pages = nil
+	delta, err = client.User.SyncWatched(context.Background(), "someguy", since)
+	require.NoError(t, err)
+	require.Len(t, delta.Added, 3)
+	require.Empty(t, delta.Removed)
+	require.Len(t, delta.State.Films, 321)
+	require.Equal(t, []string{"1"}, pages)
+	require.NotNil(t, delta.Added[0].ExternalIDs)
+}
+
+func TestSyncWatchedRemoved(t *testing.T) {
+	var pages []string
+	srv := newSyncTestServer(t, 321, &pages)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	first, err := client.User.SyncWatched(context.Background(), "someguy", nil)
+	require.NoError(t, err)
+
+	// A film that's since been removed throws the count off, so everything is
+	// walked again
+	gone := &Film{Slug: "gone-film", Target: "/film/gone-film/"}
+	since := &SyncState{User: "someguy", Films: append(append([]*Film{}, first.State.Films[3:]...), gone)}
+	pages = nil
+	delta, err := client.User.SyncWatched(context.Background(), "someguy", since)
+	require.NoError(t, err)
+	require.Len(t, delta.Added, 3)
+	require.Equal(t, []*Film{gone}, delta.Removed)
+	require.Len(t, delta.State.Films, 321)
+	require.Equal(t, []string{"1", "1", "2", "3", "4", "5"}, pages)
+}
+
+func TestSyncWatchedWrongUser(t *testing.T) {
+	client := newTestClient("http://127.0.0.1:0")
+	_, err := client.User.SyncWatched(context.Background(), "someguy", &SyncState{User: "otherguy"})
+	require.Error(t, err)
+}
+
+func TestSyncWatchedWithStore(t *testing.T) {
+	var pages []string
+	srv := newSyncTestServer(t, 321, &pages)
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+	store := NewMemorySyncStateStore()
+
+	delta, err := SyncWatchedWithStore(context.Background(), client.User, store, "someguy")
+	require.NoError(t, err)
+	require.Len(t, delta.Added, 321)
+
+	delta, err = SyncWatchedWithStore(context.Background(), client.User, store, "someguy")
+	require.NoError(t, err)
+	require.Empty(t, delta.Added)
+	require.Empty(t, delta.Removed)
+
+	state, err := store.Load("someguy")
+	require.NoError(t, err)
+	require.Equal(t, delta.State, state)
+}
+
+func TestFileSyncStateStore(t *testing.T) {
+	store, err := NewFileSyncStateStore(t.TempDir())
+	require.NoError(t, err)
+
+	state, err := store.Load("someguy")
+	require.NoError(t, err)
+	require.Nil(t, state)
+
+	saved := &SyncState{User: "someguy", Films: []*Film{{Slug: "sweet-sweetbacks-baadasssss-song", Title: "Sweet Sweetback's Baadasssss Song"}}}
+	require.NoError(t, store.Save(saved))
+	state, err = store.Load("someguy")
+	require.NoError(t, err)
+	require.Equal(t, saved.Films, state.Films)
+
+	require.Error(t, store.Save(&SyncState{User: "../someguy"}))
+	_, err = store.Load("../someguy")
+	require.Error(t, err)
+
+	_, err = NewFileSyncStateStore("")
+	require.Error(t, err)
+}
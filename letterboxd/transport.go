@@ -0,0 +1,251 @@
+package letterboxd
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apex/log"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultRetryAttempts is how many times a request is tried, including the
+	// first attempt
+	DefaultRetryAttempts  = 4
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay  = 30 * time.Second
+)
+
+// RetryOptions controls how failed requests are retried
+type RetryOptions struct {
+	MaxAttempts int           // Attempts per request, including the first. Use 1 to disable retries
+	Budget      int           // Retries allowed across the whole client. 0 means no limit
+	BaseDelay   time.Duration // Starting delay for the exponential backoff
+	MaxDelay    time.Duration // Longest wait between attempts. A longer Retry-After is not retried, and ends up as a RateLimitError
+}
+
+func (o *RetryOptions) setDefaults() {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultRetryAttempts
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = DefaultRetryBaseDelay
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = DefaultRetryMaxDelay
+	}
+}
+
+// RetryTransport retries idempotent requests that fail with a network error,
+// a 429 or a 5xx, using jittered exponential backoff
+type RetryTransport struct {
+	roundTripperWrap http.RoundTripper
+	opts             RetryOptions
+	budget           int64 // Retries left, or -1 for no limit
+}
+
+// NewRetryTransport wraps transportWrap with retries
+// example usage:
+// client := &http.Client{}
+// client.Transport = NewRetryTransport(RetryOptions{MaxAttempts: 3}, http.DefaultTransport)
+func NewRetryTransport(opts RetryOptions, transportWrap http.RoundTripper) http.RoundTripper {
+	opts.setDefaults()
+	budget := int64(-1)
+	if opts.Budget > 0 {
+		budget = int64(opts.Budget)
+	}
+	return &RetryTransport{
+		roundTripperWrap: transportWrap,
+		opts:             opts,
+		budget:           budget,
+	}
+}
+
+func (c *RetryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if !isRetryable(r) {
+		return c.roundTripperWrap.RoundTrip(r)
+	}
+	for attempt := 1; ; attempt++ {
+		res, err := c.roundTripperWrap.RoundTrip(r)
+		if res != nil && shouldRetry(res, err) && parseRetryAfter(res.Header.Get("Retry-After")) > c.opts.MaxDelay {
+			// Retrying before the time letterboxd asked for would only be
+			// refused again, so hand the response back as it is
+			log.WithFields(log.Fields{
+				"url":         r.URL.String(),
+				"retry-after": res.Header.Get("Retry-After"),
+			}).Debug("Retry-After is longer than MaxDelay, not retrying")
+			return res, err
+		}
+		if !shouldRetry(res, err) || attempt >= c.opts.MaxAttempts || r.Context().Err() != nil || !c.takeFromBudget() {
+			return res, err
+		}
+		delay := Backoff(attempt, c.opts.BaseDelay, c.opts.MaxDelay)
+		if res != nil {
+			if ra := parseRetryAfter(res.Header.Get("Retry-After")); ra > 0 {
+				delay = ra
+			}
+			// Drain the body so the connection can be reused
+			io.Copy(io.Discard, res.Body) // nolint:errcheck
+			res.Body.Close()
+		}
+		if delay > c.opts.MaxDelay {
+			delay = c.opts.MaxDelay
+		}
+		log.WithFields(log.Fields{
+			"url":     r.URL.String(),
+			"attempt": attempt,
+			"delay":   delay,
+		}).Debug("Retrying request")
+
+		t := time.NewTimer(delay)
+		select {
+		case <-r.Context().Done():
+			t.Stop()
+			return nil, r.Context().Err()
+		case <-t.C:
+		}
+		if r.GetBody != nil {
+			if r.Body, err = r.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// takeFromBudget reports if the client may make another retry
+func (c *RetryTransport) takeFromBudget() bool {
+	if atomic.LoadInt64(&c.budget) < 0 {
+		return true
+	}
+	if atomic.AddInt64(&c.budget, -1) < 0 {
+		log.Warn("Retry budget exhausted, not retrying")
+		return false
+	}
+	return true
+}
+
+// Backoff returns a random delay between zero and the exponential backoff for
+// the given attempt ("full jitter"), starting at base and capped at max
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	d := base << (attempt - 1)
+	if d <= 0 || d > max {
+		d = max
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// isRetryable only allows requests that are safe to send more than once
+func isRetryable(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	return r.Body == nil || r.Body == http.NoBody || r.GetBody != nil
+}
+
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// HostThrottledTransport rate limits requests separately for each host
+type HostThrottledTransport struct {
+	roundTripperWrap http.RoundTripper
+	limiters         map[string]*rate.Limiter
+}
+
+// NewHostThrottledTransport wraps transportWrap with a rate limiter per host.
+// rates maps a hostname to the requests per second allowed for it and any of
+// its subdomains. Hosts that are not in the map are not limited
+func NewHostThrottledTransport(rates map[string]float64, burst int, transportWrap http.RoundTripper) http.RoundTripper {
+	limiters := map[string]*rate.Limiter{}
+	for host, r := range rates {
+		limiters[strings.ToLower(host)] = rate.NewLimiter(rate.Limit(r), burst)
+	}
+	return &HostThrottledTransport{
+		roundTripperWrap: transportWrap,
+		limiters:         limiters,
+	}
+}
+
+func (c *HostThrottledTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if l := c.limiterFor(r.URL.Hostname()); l != nil {
+		if err := l.Wait(r.Context()); err != nil {
+			return nil, err
+		}
+	}
+	return c.roundTripperWrap.RoundTrip(r)
+}
+
+func (c *HostThrottledTransport) limiterFor(host string) *rate.Limiter {
+	host = strings.ToLower(host)
+	for host != "" {
+		if l, ok := c.limiters[host]; ok {
+			return l
+		}
+		i := strings.Index(host, ".")
+		if i < 0 {
+			break
+		}
+		host = host[i+1:]
+	}
+	return nil
+}
+
+// ConcurrencyTransport caps the number of requests in flight at once. A
+// request holds its slot until the response body is closed
+type ConcurrencyTransport struct {
+	roundTripperWrap http.RoundTripper
+	sem              chan struct{}
+}
+
+// NewConcurrencyTransport wraps transportWrap so that at most limit requests
+// are running at the same time
+func NewConcurrencyTransport(limit int, transportWrap http.RoundTripper) http.RoundTripper {
+	return &ConcurrencyTransport{
+		roundTripperWrap: transportWrap,
+		sem:              make(chan struct{}, limit),
+	}
+}
+
+func (c *ConcurrencyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	select {
+	case c.sem <- struct{}{}:
+	case <-r.Context().Done():
+		return nil, r.Context().Err()
+	}
+	res, err := c.roundTripperWrap.RoundTrip(r)
+	if err != nil {
+		<-c.sem
+		return nil, err
+	}
+	res.Body = &releasingBody{ReadCloser: res.Body, release: func() { <-c.sem }}
+	return res, nil
+}
+
+// releasingBody calls release the first time it is closed
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,112 @@
+package letterboxd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport(t *testing.T) {
+	var calls int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&calls, 1)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/flaky") && n < 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case strings.HasSuffix(r.URL.Path, "/down"):
+			w.WriteHeader(http.StatusBadGateway)
+		case strings.HasSuffix(r.URL.Path, "/missing"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	newClient := func(opts RetryOptions) *http.Client {
+		opts.BaseDelay = time.Millisecond
+		return &http.Client{Transport: NewRetryTransport(opts, http.DefaultTransport)}
+	}
+
+	tests := []struct {
+		method    string
+		path      string
+		opts      RetryOptions
+		wantCode  int
+		wantCalls int64
+	}{
+		{http.MethodGet, "/flaky", RetryOptions{}, http.StatusOK, 3},
+		{http.MethodGet, "/flaky", RetryOptions{MaxAttempts: 2}, http.StatusServiceUnavailable, 2},
+		{http.MethodGet, "/down", RetryOptions{MaxAttempts: 3}, http.StatusBadGateway, 3},
+		{http.MethodGet, "/missing", RetryOptions{}, http.StatusNotFound, 1},
+		{http.MethodPost, "/flaky", RetryOptions{}, http.StatusServiceUnavailable, 1},
+		{http.MethodGet, "/down", RetryOptions{MaxAttempts: 10, Budget: 2}, http.StatusBadGateway, 3},
+	}
+	for _, tt := range tests {
+		atomic.StoreInt64(&calls, 0)
+		req, err := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+		require.NoError(t, err)
+		res, err := newClient(tt.opts).Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		require.Equal(t, tt.wantCode, res.StatusCode, tt.path)
+		require.Equal(t, tt.wantCalls, atomic.LoadInt64(&calls), tt.path)
+	}
+}
+
+func TestRetryTransportRetryAfter(t *testing.T) {
+	var calls int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewRetryTransport(RetryOptions{BaseDelay: time.Millisecond}, http.DefaultTransport)}
+	start := time.Now()
+	res, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	// A Retry-After past MaxDelay isn't retried early, the 429 comes straight
+	// back instead
+	atomic.StoreInt64(&calls, 0)
+	client = &http.Client{Transport: NewRetryTransport(RetryOptions{MaxDelay: 10 * time.Millisecond}, http.DefaultTransport)}
+	start = time.Now()
+	res, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	require.Equal(t, int64(1), atomic.LoadInt64(&calls))
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestScrapeClientLongRetryAfter(t *testing.T) {
+	var calls int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&calls, 1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	_, err := client.Film.Get(context.Background(), "sweet-sweetbacks-baadasssss-song")
+	var rerr *RateLimitError
+	require.True(t, errors.As(err, &rerr))
+	require.Equal(t, 2*time.Minute, rerr.RetryAfter)
+	require.Equal(t, int64(1), atomic.LoadInt64(&calls))
+}
@@ -3,7 +3,6 @@ package letterboxd
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/url"
 	"strings"
 
@@ -11,39 +10,31 @@ import (
 )
 
 type URLService interface {
-	Items(ctx *context.Context, url string) (interface{}, error)
+	Items(ctx context.Context, url string) (interface{}, error)
 }
 
 type URLServiceOp struct {
 	client *ScrapeClient
 }
 
-func (u *URLServiceOp) Items(ctx *context.Context, lurl string) (interface{}, error) {
+func (u *URLServiceOp) Items(ctx context.Context, lurl string) (interface{}, error) {
 	path, err := normalizeURLPath(lurl)
 	if err != nil {
 		return nil, err
 	}
-	client := NewScrapeClient(nil)
-	// Check if this is a filmography first
-	professions := GetFilmographyProfessions()
-	for _, profession := range professions {
-		if strings.HasPrefix(path, fmt.Sprintf("/%v/", profession)) {
-			actor := strings.Split(path, "/")[2]
-			log.WithFields(log.Fields{
-				"path":       path,
-				"profession": profession,
-				"actor":      actor,
-			}).Debug("Detected filmography")
-			items, err := u.client.Film.Filmography(nil, &FilmographyOpt{
-				Profession: profession,
-				Person:     actor,
-			})
-			if err != nil {
-				return nil, err
-			}
-			return items, nil
-
+	// Collections look like '/films/in/halloween-collection', maybe with a sort
+	// order on the end
+	if strings.HasPrefix(path, "/films/in/") {
+		slug := strings.Split(path, "/")[3]
+		log.WithFields(log.Fields{
+			"path":       path,
+			"collection": slug,
+		}).Debug("Detected collection")
+		collection, err := u.client.Film.Collection(ctx, slug)
+		if err != nil {
+			return nil, err
 		}
+		return collection.Films, nil
 	}
 	// Handle Watchlist
 	if strings.HasSuffix(path, "/watchlist") {
@@ -52,7 +43,7 @@ func (u *URLServiceOp) Items(ctx *context.Context, lurl string) (interface{}, er
 			"path": path,
 			"user": user,
 		}).Debug("Detected watchlist")
-		items, _, err := u.client.User.WatchList(nil, user)
+		items, _, err := u.client.User.WatchList(ctx, user)
 		if err != nil {
 			return nil, err
 		}
@@ -68,7 +59,7 @@ func (u *URLServiceOp) Items(ctx *context.Context, lurl string) (interface{}, er
 			"user": user,
 			"list": list,
 		}).Info("Detected user list")
-		items, err := client.List.ListFilms(nil, &ListFilmsOpt{
+		items, err := u.client.List.ListFilms(ctx, &ListFilmsOpt{
 			User:     user,
 			Slug:     list,
 			LastPage: -1,
@@ -76,7 +67,7 @@ func (u *URLServiceOp) Items(ctx *context.Context, lurl string) (interface{}, er
 		if err != nil {
 			return nil, err
 		}
-		return items, nil
+		return items.Films(), nil
 	}
 	if strings.HasSuffix(path, "/films") {
 		user := strings.Split(path, "/")[1]
@@ -84,13 +75,36 @@ func (u *URLServiceOp) Items(ctx *context.Context, lurl string) (interface{}, er
 			"path": path,
 			"user": user,
 		}).Debug("Detected user films")
-		items, _, err := client.User.Watched(nil, user)
+		items, _, err := u.client.User.Watched(ctx, user)
 		if err != nil {
 			return nil, err
 		}
 		return items, nil
 	}
 
+	// Filmographies are '/<profession>/<person>'. They're checked last, since
+	// some professions, like 'sound', are usernames too, and '/sound/watchlist'
+	// is that user's watchlist
+	parts := strings.Split(path, "/")
+	for _, profession := range GetFilmographyProfessions() {
+		if len(parts) == 3 && parts[1] == profession {
+			actor := parts[2]
+			log.WithFields(log.Fields{
+				"path":       path,
+				"profession": profession,
+				"actor":      actor,
+			}).Debug("Detected filmography")
+			items, err := u.client.Film.Filmography(ctx, &FilmographyOpt{
+				Profession: profession,
+				Person:     actor,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return items, nil
+
+		}
+	}
 	// Default fail
 	return nil, errors.New("Could not find a match for that URL")
 }
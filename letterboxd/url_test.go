@@ -1,6 +1,7 @@
 package letterboxd
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -13,8 +14,8 @@ import (
 )
 
 func TestURLFilmographyBadProfession(t *testing.T) {
-	client := NewScrapeClient(nil)
-	_, err := client.URL.Items(nil, "https://www.letterboxd.com/televangelist/nicolas-cage")
+	client := NewScrapeClient(nil, nil)
+	_, err := client.URL.Items(context.Background(), "https://www.letterboxd.com/televangelist/nicolas-cage")
 	require.Error(t, err)
 }
 
@@ -44,15 +45,45 @@ func TestURLFilmographyActor(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	client := NewScrapeClient(nil)
-	client.BaseURL = srv.URL
+	client := newTestClient(srv.URL)
 
-	items, err := client.URL.Items(nil, "https://www.letterboxd.com/actor/nicolas-cage")
+	items, err := client.URL.Items(context.Background(), "https://www.letterboxd.com/actor/nicolas-cage")
 	require.NoError(t, err)
 	require.IsType(t, []*Film{}, items)
 	require.Greater(t, len(items.([]*Film)), 0)
 }
 
+func TestURLUserNamedLikeProfession(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var path string
+		switch {
+		case r.URL.Path == "/sound/watchlist/page/1":
+			// A single page grid, so the watchlist stops after it
+			path = "testdata/user/watched-films-single.html"
+		case r.URL.Path == "/casting/list/x/page/1":
+			path = "testdata/list/lists-single-page.html"
+		case strings.HasPrefix(r.URL.Path, "/film/"):
+			path = "testdata/film/sweetback.html"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open(path)
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+	client := newTestClient(srv.URL)
+
+	for _, lurl := range []string{"/sound/watchlist/", "/casting/list/x/"} {
+		items, err := client.URL.Items(context.Background(), lurl)
+		require.NoError(t, err, lurl)
+		require.NotEmpty(t, items.([]*Film), lurl)
+	}
+}
+
 func TestNormalizeURLPath(t *testing.T) {
 	tests := []struct {
 		ourl         string
@@ -104,8 +135,7 @@ func TestURLWatchList(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	client := NewScrapeClient(nil)
-	client.BaseURL = srv.URL
+	client := newTestClient(srv.URL)
 
 	ctx := context.Background()
 	items, err := client.URL.Items(&ctx, "https://www.letterboxd.com/mondodrew/watchlist")
@@ -141,12 +171,12 @@ func TestURLUserList(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	client := NewScrapeClient(nil)
+	client := newTestClient("")
 	client.BaseURL = srv.URL
 
 	/*
 		TODO: Need to mock this better
-		items, err := client.URL.Items(nil, fmt.Sprintf("rboxd.com/dave/list/official-top-250-narrative-feature-films/")
+		items, err := client.URL.Items(context.Background(), fmt.Sprintf("rboxd.com/dave/list/official-top-250-narrative-feature-films/")
 		require.NoError(t, err)
 		require.IsType(t, []*Film{}, items)
 		require.Equal(t, len(items.([]*Film)), 250)
@@ -23,12 +23,31 @@ type UserService interface {
 	StreamWatchListWithChan(context.Context, string, chan *Film, chan error)
 	Exists(context.Context, string) (bool, error)
 	Profile(context.Context, string) (*User, *Response, error)
+	Ratings(context.Context, string) (*UserRatings, error)
+	Following(context.Context, string) ([]*User, error)
+	Followers(context.Context, string) ([]*User, error)
+	SocialGraph(context.Context, string, *SocialGraphOpt) (*SocialGraph, error)
+	Diary(context.Context, string, *DiaryOpt) ([]*DiaryEntry, error)
+	StreamDiaryWithChan(context.Context, string, *DiaryOpt, chan *DiaryEntry, chan error)
+	SyncWatched(context.Context, string, *SyncState) (*WatchedDelta, error)
 }
 
 type User struct {
-	Username         string `json:"username"`
-	Bio              string `json:"bio"`
-	WatchedFilmCount int    `json:"watched_film_count"`
+	Username         string          `json:"username"`
+	DisplayName      string          `json:"display_name,omitempty"`
+	Bio              string          `json:"bio"`
+	AvatarURL        string          `json:"avatar_url,omitempty"`
+	Location         string          `json:"location,omitempty"`
+	Website          string          `json:"website,omitempty"`
+	Pro              bool            `json:"pro"`
+	Patron           bool            `json:"patron"`
+	WatchedFilmCount int             `json:"watched_film_count"`
+	FilmsThisYear    int             `json:"films_this_year"`
+	ListCount        int             `json:"list_count"`
+	FollowingCount   int             `json:"following_count"`
+	FollowerCount    int             `json:"follower_count"`
+	Favorites        []*Film         `json:"favorites,omitempty"`
+	RatingHistogram  RatingHistogram `json:"rating_histogram"`
 }
 
 type UserServiceOp struct {
@@ -49,30 +68,68 @@ func ExtractUser(r io.Reader) (interface{}, *Pagination, error) {
 	doc.Find("section.js-profile-header").Each(func(i int, s *goquery.Selection) {
 		user.Username = s.AttrOr("data-person", "")
 	})
-	doc.Find("div.profile-stats").Each(func(i int, s *goquery.Selection) {
-		s.Find("a").Each(func(i int, s *goquery.Selection) {
-			if s.AttrOr("href", "") == fmt.Sprintf("/%v/films/", user.Username) {
-				s.Find("span.value").Each(func(i int, s *goquery.Selection) {
-					countS := strings.TrimSpace(s.Text())
-					countS = strings.Replace(countS, ",", "", -1)
-					count, err := strconv.Atoi(countS)
-					if err != nil {
-						log.WithError(err).Warn("Failed to parse film count")
-					}
-					user.WatchedFilmCount = count
-				})
-				// user.WatchedFilmCount, _ = s.AttrOr("data-count", "").Atoi()
-			}
-		})
-	})
 	if user.Username == "" {
-		return nil, nil, fmt.Errorf("Failed to extract user")
+		return nil, nil, &ParseError{Selector: "section.js-profile-header[data-person]"}
 	}
+
+	name := doc.Find("div.profile-name h1.title-1").First()
+	user.DisplayName = strings.TrimSpace(name.AttrOr("title", name.Text()))
+	user.AvatarURL = doc.Find("div.profile-avatar img").First().AttrOr("src", "")
+	user.Pro = doc.Find("div.profile-name span.badge.-pro").Length() > 0
+	user.Patron = doc.Find("div.profile-name span.badge.-patron").Length() > 0
+
+	// Each stat links to its own page, which tells us what it is
+	doc.Find("div.profile-stats h4 a").Each(func(i int, s *goquery.Selection) {
+		count := profileStatValue(s)
+		href := strings.TrimPrefix(s.AttrOr("href", ""), fmt.Sprintf("/%v/", user.Username))
+		switch {
+		case href == "films/":
+			user.WatchedFilmCount = count
+		case strings.HasPrefix(href, "films/diary/for/"):
+			user.FilmsThisYear = count
+		case href == "lists/":
+			user.ListCount = count
+		case href == "following/":
+			user.FollowingCount = count
+		case href == "followers/":
+			user.FollowerCount = count
+		}
+	})
+
+	doc.Find("div.profile-metadata .metadatum").Each(func(i int, s *goquery.Selection) {
+		href, isLink := s.Attr("href")
+		switch {
+		case !isLink && user.Location == "":
+			user.Location = strings.TrimSpace(s.Find("span.label").Text())
+		case isLink && user.Website == "" && !strings.Contains(href, "twitter.com/"):
+			user.Website = href
+		}
+	})
+
+	user.Favorites = []*Film{}
+	doc.Find("section#favourites div.film-poster").Each(func(i int, s *goquery.Selection) {
+		user.Favorites = append(user.Favorites, &Film{
+			ID:     s.AttrOr("data-film-id", ""),
+			Slug:   normalizeSlug(s.AttrOr("data-film-slug", "")),
+			Target: s.AttrOr("data-target-link", ""),
+			Title:  s.Find("img.image").AttrOr("alt", ""),
+		})
+	})
+
+	user.RatingHistogram = extractRatingHistogram(doc)
 	return user, nil, nil
 }
 
+// profileStatValue reads the number from a profile stat, like '1,398'. Stats
+// that are missing or hidden are zero
+func profileStatValue(s *goquery.Selection) int {
+	v := strings.ReplaceAll(strings.TrimSpace(s.Find("span.value").Text()), ",", "")
+	count, _ := strconv.Atoi(v)
+	return count
+}
+
 func (u *UserServiceOp) Profile(ctx context.Context, userID string) (*User, *Response, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", u.client.BaseURL, userID), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s", u.client.BaseURL, userID), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -91,7 +148,7 @@ func (u *UserServiceOp) WatchList(ctx context.Context, userID string) ([]*Film,
 	var previews []*Film
 	page := 1
 	for {
-		req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s/watchlist/page/%d", u.client.BaseURL, userID, page), nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s/watchlist/page/%d", u.client.BaseURL, userID, page), nil)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -116,19 +173,27 @@ func (u *UserServiceOp) WatchList(ctx context.Context, userID string) ([]*Film,
 
 func (u *UserServiceOp) StreamWatchedWithChan(ctx context.Context, userID string, rchan chan *Film, done chan error) {
 	var err error
-	var pagination *Pagination
 	defer func() {
 		log.Debug("Closing STREAMWATCHED")
-		done <- nil
+		done <- err
 	}()
 	log.Debug("About to start streaming fims")
+	err = u.streamFilmPages(ctx, func(page int) string {
+		return fmt.Sprintf("%s/%s/films/page/%v/", u.client.BaseURL, userID, page)
+	}, rchan)
+}
+
+// streamFilmPages sends the films from every page of a paginated film grid
+// down rchan. The first page seeds the pagination, then the last and middle
+// pages are fetched in parallel. Returns early if ctx is cancelled
+func (u *UserServiceOp) streamFilmPages(ctx context.Context, pageURL func(int) string, rchan chan *Film) error {
 	// Get the first page. This seeds the pagination.
-	firstFilms, pagination, err := u.client.Film.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s/%s/films/page/1", u.client.BaseURL, userID))
+	firstFilms, pagination, err := u.client.Film.ExtractEnhancedFilmsWithPath(ctx, pageURL(1))
 	if err != nil {
-		done <- err
+		return err
 	}
-	for _, film := range firstFilms {
-		rchan <- film
+	if err = sendFilms(ctx, rchan, firstFilms); err != nil {
+		return err
 	}
 
 	itemsPerFullPage := len(firstFilms)
@@ -138,87 +203,120 @@ func (u *UserServiceOp) StreamWatchedWithChan(ctx context.Context, userID string
 	// partial batch of films
 	if pagination.TotalPages > 1 {
 		var lastFilms []*Film
-		lastFilms, _, err = u.client.Film.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s/%s/films/page/%v", u.client.BaseURL, userID, pagination.TotalPages))
+		lastFilms, _, err = u.client.Film.ExtractEnhancedFilmsWithPath(ctx, pageURL(pagination.TotalPages))
 		if err != nil {
-			done <- err
+			return err
 		}
 		pagination.TotalItems = pagination.TotalItems + len(lastFilms)
-		for _, film := range lastFilms {
-			rchan <- film
+		if err = sendFilms(ctx, rchan, lastFilms); err != nil {
+			return err
 		}
 	}
 	// Gather up the middle pages here
 	if pagination.TotalPages > 2 {
 		pagination.TotalItems = pagination.TotalItems + ((pagination.TotalPages - 2) * itemsPerFullPage)
-		middlePageCount := pagination.TotalPages - 2
-		wg := sync.WaitGroup{}
-		wg.Add(middlePageCount)
-		for i := 2; i < pagination.TotalPages; i++ {
-			go func(i int) {
-				defer wg.Done()
-				pfilms, _, err := u.client.Film.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s/%s/films/page/%v/", u.client.BaseURL, userID, i))
-				if err != nil {
-					log.WithFields(log.Fields{
-						"page": i,
-						"user": userID,
-					}).Warn("Failed to extract films")
-					return
-				}
-				for _, film := range pfilms {
-					rchan <- film
-				}
-			}(i)
+	}
+	return u.sendFilmPages(ctx, pageURL, 2, pagination.TotalPages-1, func(films []*Film) error {
+		return sendFilms(ctx, rchan, films)
+	})
+}
+
+// sendFilmPages fetches pages first through last of a film grid in parallel,
+// handing the films on each page to send. If a page fails, the rest are
+// abandoned and its error is returned, so callers never mistake a partial
+// grid for the whole thing
+func (u *UserServiceOp) sendFilmPages(ctx context.Context, pageURL func(int) string, first, last int, send func([]*Film) error) error {
+	pageCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var errOnce sync.Once
+	var pageErr error
+	setErr := func(err error) {
+		errOnce.Do(func() {
+			pageErr = err
+			cancel()
+		})
+	}
+	var wg sync.WaitGroup
+	for i := first; i <= last; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pfilms, _, err := u.client.Film.ExtractEnhancedFilmsWithPath(pageCtx, pageURL(i))
+			if err != nil {
+				setErr(fmt.Errorf("page %v: %w", i, err))
+				return
+			}
+			if err := send(pfilms); err != nil {
+				setErr(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return pageErr
+}
+
+// sendFilms sends films down rchan, giving up if ctx is cancelled
+func sendFilms(ctx context.Context, rchan chan *Film, films []*Film) error {
+	for _, film := range films {
+		select {
+		case rchan <- film:
+		case <-ctx.Done():
+			return ctx.Err()
 		}
-		wg.Wait()
 	}
+	return nil
 }
 
+// StreamWatched sends the films from each page of a user's watched history
+// down the returned channel, one page at a time. The first and last pages are
+// fetched before it returns, so the pagination is known, and the rest follow
+// in the background. The channel is closed once every page is sent, or ctx is
+// cancelled
 func (u *UserServiceOp) StreamWatched(ctx context.Context, userID string) (chan []*Film, *Pagination, error) {
-	var err error
-	var pagination *Pagination
-	log.Debug("Starting STREAMWATCHED")
+	pageURL := func(page int) string {
+		return fmt.Sprintf("%s/%s/films/page/%v/", u.client.BaseURL, userID, page)
+	}
 	// Get the first page. This seeds the pagination.
-	firstFilms, pagination, err := u.client.Film.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s/%s/films/page/1", u.client.BaseURL, userID))
+	firstFilms, pagination, err := u.client.Film.ExtractEnhancedFilmsWithPath(ctx, pageURL(1))
 	if err != nil {
 		return nil, nil, err
 	}
 	rchan := make(chan []*Film, pagination.TotalPages)
-	log.Debugf("ABOUT TO DUMP FIRST PARTIAL FILMS: %+v", firstFilms)
 	rchan <- firstFilms
-	// rchan <- partialFilms
 
 	itemsPerFullPage := len(firstFilms)
 	pagination.TotalItems = itemsPerFullPage
 
 	// If more than 1 page, get the last page too, which will likely be a
 	// partial batch of films
-	log.Debug("GONNA LOOK AT GT 1")
 	if pagination.TotalPages > 1 {
-		var lastFilms []*Film
-		lastFilms, _, err = u.client.Film.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s/%s/films/page/%v", u.client.BaseURL, userID, pagination.TotalPages))
+		lastFilms, _, err := u.client.Film.ExtractEnhancedFilmsWithPath(ctx, pageURL(pagination.TotalPages))
 		if err != nil {
 			return nil, nil, err
 		}
 		pagination.TotalItems = pagination.TotalItems + len(lastFilms)
 		rchan <- lastFilms
 	}
-	// Gather up the middle pages here
 	if pagination.TotalPages > 2 {
 		pagination.TotalItems = pagination.TotalItems + ((pagination.TotalPages - 2) * itemsPerFullPage)
-		for i := 2; i < pagination.TotalPages; i++ {
-			go func(i int) {
-				pfilms, _, err := u.client.Film.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s/%s/films/page/%v/", u.client.BaseURL, userID, i))
-				if err != nil {
-					log.WithFields(log.Fields{
-						"page": i,
-						"user": userID,
-					}).Warn("Failed to extract films")
-					return
-				}
-				rchan <- pfilms
-			}(i)
-		}
 	}
+	go func() {
+		defer close(rchan)
+		err := u.sendFilmPages(ctx, pageURL, 2, pagination.TotalPages-1, func(films []*Film) error {
+			select {
+			case rchan <- films:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			log.WithError(err).WithField("user", userID).Warn("Failed to stream watched films")
+		}
+	}()
 	return rchan, pagination, nil
 }
 
@@ -254,9 +352,10 @@ func ExtractUserFilms(r io.Reader) (interface{}, *Pagination, error) {
 		return nil, nil, err
 	}
 	doc.Find("li.poster-container").Each(func(i int, s *goquery.Selection) {
+		var f *Film
 		s.Find("div").Each(func(i int, s *goquery.Selection) {
 			if s.HasClass("film-poster") {
-				f := Film{}
+				f = &Film{}
 				f.ID = s.AttrOr("data-film-id", "")
 				// f.Slug = s.AttrOr("data-film-slug", "")
 				f.Slug = normalizeSlug(s.AttrOr("data-film-slug", ""))
@@ -265,9 +364,15 @@ func ExtractUserFilms(r io.Reader) (interface{}, *Pagination, error) {
 				s.Find("img.image").Each(func(i int, s *goquery.Selection) {
 					f.Title = s.AttrOr("alt", "")
 				})
-				previews = append(previews, &f)
+				previews = append(previews, f)
 			}
 		})
+		// The member's own rating and like sit under the poster
+		if f != nil {
+			viewing := s.Find("p.poster-viewingdata")
+			f.UserRating = starsFromRatedClass(viewing.Find("span.rating"))
+			f.UserLiked = viewing.Find("span.like").Length() > 0
+		}
 	})
 	pagination, err := ExtractPaginationWithReader(&pageBuf)
 	if err != nil {
@@ -290,59 +395,13 @@ func (u *UserServiceOp) StreamListWithChan(
 	done chan error,
 ) {
 	var err error
-	var pagination *Pagination
 	defer func() {
 		log.Debug("Closing StreamListWithChan")
-		done <- nil
-	}()
-	firstFilms, pagination, err := u.client.Film.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s/%s/list/%s/page/1", u.client.BaseURL, username, slug))
-	if err != nil {
 		done <- err
-	}
-	for _, film := range firstFilms {
-		rchan <- film
-	}
-
-	itemsPerFullPage := len(firstFilms)
-	pagination.TotalItems = itemsPerFullPage
-
-	// If more than 1 page, get the last page too, which will likely be a
-	// partial batch of films
-	if pagination.TotalPages > 1 {
-		var lastFilms []*Film
-		lastFilms, _, err = u.client.Film.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s/%s/list/%s/page/%v", u.client.BaseURL, username, slug, pagination.TotalPages))
-		if err != nil {
-			done <- err
-		}
-		pagination.TotalItems = pagination.TotalItems + len(lastFilms)
-		for _, film := range lastFilms {
-			rchan <- film
-		}
-	}
-	// Gather up the middle pages here
-	if pagination.TotalPages > 2 {
-		pagination.TotalItems = pagination.TotalItems + ((pagination.TotalPages - 2) * itemsPerFullPage)
-		middlePageCount := pagination.TotalPages - 2
-		wg := sync.WaitGroup{}
-		wg.Add(middlePageCount)
-		for i := 2; i < pagination.TotalPages; i++ {
-			go func(i int) {
-				defer wg.Done()
-				pfilms, _, err := u.client.Film.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s/%s/list/%v/page/%v/", u.client.BaseURL, username, slug, i))
-				if err != nil {
-					log.WithFields(log.Fields{
-						"page": i,
-						"user": username,
-					}).Warn("Failed to extract films")
-					return
-				}
-				for _, film := range pfilms {
-					rchan <- film
-				}
-			}(i)
-		}
-		wg.Wait()
-	}
+	}()
+	err = u.streamFilmPages(ctx, func(page int) string {
+		return fmt.Sprintf("%s/%s/list/%s/page/%v/", u.client.BaseURL, username, slug, page)
+	}, rchan)
 }
 
 func (u *UserServiceOp) StreamWatchListWithChan(
@@ -352,57 +411,11 @@ func (u *UserServiceOp) StreamWatchListWithChan(
 	done chan error,
 ) {
 	var err error
-	var pagination *Pagination
 	defer func() {
 		log.Debug("Closing StreamWatchListWithChan")
-		done <- nil
-	}()
-	firstFilms, pagination, err := u.client.Film.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s/%s/watchlist/page/1", u.client.BaseURL, username))
-	if err != nil {
 		done <- err
-	}
-	for _, film := range firstFilms {
-		rchan <- film
-	}
-
-	itemsPerFullPage := len(firstFilms)
-	pagination.TotalItems = itemsPerFullPage
-
-	// If more than 1 page, get the last page too, which will likely be a
-	// partial batch of films
-	if pagination.TotalPages > 1 {
-		var lastFilms []*Film
-		lastFilms, _, err = u.client.Film.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s/%s/watchlist/page/%v", u.client.BaseURL, username, pagination.TotalPages))
-		if err != nil {
-			done <- err
-		}
-		pagination.TotalItems = pagination.TotalItems + len(lastFilms)
-		for _, film := range lastFilms {
-			rchan <- film
-		}
-	}
-	// Gather up the middle pages here
-	if pagination.TotalPages > 2 {
-		pagination.TotalItems = pagination.TotalItems + ((pagination.TotalPages - 2) * itemsPerFullPage)
-		middlePageCount := pagination.TotalPages - 2
-		wg := sync.WaitGroup{}
-		wg.Add(middlePageCount)
-		for i := 2; i < pagination.TotalPages; i++ {
-			go func(i int) {
-				defer wg.Done()
-				pfilms, _, err := u.client.Film.ExtractEnhancedFilmsWithPath(ctx, fmt.Sprintf("%s/%s/watchlist/page/%v/", u.client.BaseURL, username, i))
-				if err != nil {
-					log.WithFields(log.Fields{
-						"page": i,
-						"user": username,
-					}).Warn("Failed to extract films")
-					return
-				}
-				for _, film := range pfilms {
-					rchan <- film
-				}
-			}(i)
-		}
-		wg.Wait()
-	}
+	}()
+	err = u.streamFilmPages(ctx, func(page int) string {
+		return fmt.Sprintf("%s/%s/watchlist/page/%v/", u.client.BaseURL, username, page)
+	}, rchan)
 }
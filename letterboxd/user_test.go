@@ -1,6 +1,7 @@
 package letterboxd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/apex/log"
 	"github.com/gin-gonic/gin"
@@ -27,6 +29,11 @@ func TestExtractUserFilms(t *testing.T) {
 	require.NoError(t, err)
 	require.Greater(t, len(films), 70)
 	require.Equal(t, "Cypress Hill: Insane in the Brain", films[0].Title)
+	require.Equal(t, 2.5, films[0].UserRating)
+	require.False(t, films[0].UserLiked)
+	require.Equal(t, "The Northman", films[1].Title)
+	require.Equal(t, 5.0, films[1].UserRating)
+	require.True(t, films[1].UserLiked)
 }
 
 func TestExtractUserFilmsSinglePage(t *testing.T) {
@@ -51,6 +58,34 @@ func TestExtractUser(t *testing.T) {
 	u := user.(*User)
 	require.Equal(t, "dankmccoy", u.Username)
 	require.Equal(t, "Former writer for The Daily Show with Jon Stewart (also Trevor Noah). Podcaster -- The Flop House. I watch a lot of trash, but I also care about good stuff, I swear.", u.Bio)
+	require.Equal(t, len(RatingSteps), len(u.RatingHistogram))
+	require.Equal(t, "Dan McCoy", u.DisplayName)
+	require.Contains(t, u.AvatarURL, "https://a.ltrbxd.com/resized/avatar/")
+	require.Equal(t, "Brooklyn, NY", u.Location)
+	require.Equal(t, "http://www.flophousepodcast.com", u.Website)
+	require.True(t, u.Pro)
+	require.False(t, u.Patron)
+	require.Equal(t, 1398, u.WatchedFilmCount)
+	require.Equal(t, 114, u.FilmsThisYear)
+	require.Equal(t, 11, u.ListCount)
+	require.Equal(t, 84, u.FollowingCount)
+	require.Equal(t, 3093, u.FollowerCount)
+	require.Equal(t, 4, len(u.Favorites))
+	require.Equal(t, "animal-crackers", u.Favorites[0].Slug)
+	require.Equal(t, "The Thing", u.Favorites[3].Title)
+}
+
+func TestExtractUserSparse(t *testing.T) {
+	// A brand new member has none of the optional bits
+	user, _, err := ExtractUser(strings.NewReader(`<section class="js-profile-header" data-person="newbie"></section>`))
+	require.NoError(t, err)
+	u := user.(*User)
+	require.Equal(t, "newbie", u.Username)
+	require.Zero(t, u.WatchedFilmCount)
+	require.Zero(t, u.FollowerCount)
+	require.Empty(t, u.Location)
+	require.Empty(t, u.Favorites)
+	require.False(t, u.Pro)
 }
 
 func TestUserProfile(t *testing.T) {
@@ -62,10 +97,9 @@ func TestUserProfile(t *testing.T) {
 		io.Copy(w, f)
 	}))
 	defer srv.Close()
-	client := NewScrapeClient(nil)
-	client.BaseURL = srv.URL
+	client := newTestClient(srv.URL)
 
-	item, _, err := client.User.Profile(nil, "dankmccoy")
+	item, _, err := client.User.Profile(context.Background(), "dankmccoy")
 	require.NoError(t, err)
 	require.IsType(t, &User{}, item)
 	require.Equal(t, 1398, item.WatchedFilmCount)
@@ -82,8 +116,7 @@ func TestUserProfileExists(t *testing.T) {
 		}
 	}))
 	defer srv.Close()
-	client := NewScrapeClient(nil)
-	client.BaseURL = srv.URL
+	client := newTestClient(srv.URL)
 
 	tests := []struct {
 		user   string
@@ -94,7 +127,7 @@ func TestUserProfileExists(t *testing.T) {
 	}
 	for _, tt := range tests {
 
-		item, _, err := client.User.Profile(nil, tt.user)
+		item, _, err := client.User.Profile(context.Background(), tt.user)
 		if tt.expect {
 			require.NoError(t, err)
 			require.IsType(t, &User{}, item)
@@ -131,10 +164,9 @@ func TestListWatched(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	client := NewScrapeClient(nil)
-	client.BaseURL = srv.URL
+	client := newTestClient(srv.URL)
 
-	watched, _, err := client.User.Watched(nil, "someguy")
+	watched, _, err := client.User.Watched(context.Background(), "someguy")
 	require.NoError(t, err)
 	require.NotNil(t, watched)
 
@@ -168,14 +200,13 @@ func TestStreamWatchedWithChan(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	client := NewScrapeClient(nil)
-	client.BaseURL = srv.URL
+	client := newTestClient(srv.URL)
 
 	log.Info("Streaming movies")
 	watchedC := make(chan *Film, 0)
 	var watched []*Film
 	done := make(chan error)
-	go client.User.StreamWatchedWithChan(nil, "someguy", watchedC, done)
+	go client.User.StreamWatchedWithChan(context.Background(), "someguy", watchedC, done)
 loop:
 	for {
 		select {
@@ -193,6 +224,123 @@ loop:
 	require.Equal(t, 321, len(watched))
 }
 
+func TestStreamWatchedWithChanCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/someguy/films/page/") {
+			pageNo := strings.Split(r.URL.Path, "/")[4]
+			rp, err := os.Open(fmt.Sprintf("testdata/user/watched-paginated/%v.html", pageNo))
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		} else if strings.HasPrefix(r.URL.Path, "/film/") {
+			rp, err := os.Open("testdata/film/sweetback.html")
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watchedC := make(chan *Film)
+	done := make(chan error)
+	go client.User.StreamWatchedWithChan(ctx, "someguy", watchedC, done)
+
+	// Take a single film, then walk away
+	<-watchedC
+	cancel()
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(10 * time.Second):
+		t.Fatal("stream did not stop after cancel")
+	}
+}
+
+func TestStreamWatchedWithChanPageError(t *testing.T) {
+	// The client hangs up on the other pages once one fails, so copy errors
+	// are expected
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/someguy/films/page/3/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if strings.Contains(r.URL.Path, "/someguy/films/page/") {
+			pageNo := strings.Split(r.URL.Path, "/")[4]
+			rp, err := os.Open(fmt.Sprintf("testdata/user/watched-paginated/%v.html", pageNo))
+			require.NoError(t, err)
+			defer rp.Close()
+			io.Copy(w, rp) // nolint:errcheck
+			return
+		} else if strings.HasPrefix(r.URL.Path, "/film/") {
+			rp, err := os.Open("testdata/film/sweetback.html")
+			require.NoError(t, err)
+			defer rp.Close()
+			io.Copy(w, rp) // nolint:errcheck
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	watchedC := make(chan *Film)
+	done := make(chan error)
+	go client.User.StreamWatchedWithChan(context.Background(), "someguy", watchedC, done)
+	for {
+		select {
+		case <-watchedC:
+		case err := <-done:
+			// A missing middle page fails the stream, instead of leaving a gap
+			require.ErrorIs(t, err, ErrNotFound)
+			return
+		}
+	}
+}
+
+func TestStreamWatched(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/someguy/films/page/") {
+			pageNo := strings.Split(r.URL.Path, "/")[4]
+			rp, err := os.Open(fmt.Sprintf("testdata/user/watched-paginated/%v.html", pageNo))
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		} else if strings.HasPrefix(r.URL.Path, "/film/") {
+			rp, err := os.Open("testdata/film/sweetback.html")
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	watched, pagination, err := client.User.StreamWatched(context.Background(), "someguy")
+	require.NoError(t, err)
+	require.Equal(t, 5, pagination.TotalPages)
+	var films []*Film
+	pages := 0
+	// The channel is closed after the last page
+	for page := range watched {
+		films = append(films, page...)
+		pages++
+	}
+	require.Equal(t, 5, pages)
+	require.Equal(t, 321, len(films))
+}
+
 func TestStreamListWithChan(t *testing.T) {
 	sweetbackF, err := os.Open("testdata/film/sweetback.html")
 	defer sweetbackF.Close()
@@ -220,14 +368,13 @@ func TestStreamListWithChan(t *testing.T) {
 	}))
 	defer lsrv.Close()
 
-	client := NewScrapeClient(nil)
-	client.BaseURL = lsrv.URL
+	client := newTestClient(lsrv.URL)
 
 	log.Info("Streaming movies")
 	watchedC := make(chan *Film, 0)
 	var watched []*Film
 	done := make(chan error)
-	go client.User.StreamListWithChan(nil, "dave", "official-top-250-narrative-feature-films", watchedC, done)
+	go client.User.StreamListWithChan(context.Background(), "dave", "official-top-250-narrative-feature-films", watchedC, done)
 loop:
 	for {
 		select {
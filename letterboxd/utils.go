@@ -2,7 +2,10 @@ package letterboxd
 
 import (
 	"errors"
+	"strconv"
 	"strings"
+
+	"github.com/PuerkitoBio/goquery"
 )
 
 // 0 means undefined
@@ -22,6 +25,18 @@ func normalizeStartStop(firstPage, lastPage int) (int, int, error) {
 	return firstPage, lastPage, nil
 }
 
+// validatePages checks a page range before it's normalized. Zero means the
+// default for either page, and a lastPage of -1 means every page
+func validatePages(firstPage, lastPage int) error {
+	if firstPage < 0 {
+		return errors.New("FirstPage must be 1 or more")
+	}
+	if lastPage < -1 || (lastPage > 0 && lastPage < firstPage) {
+		return errors.New("LastPage must be -1, or no less than FirstPage")
+	}
+	return nil
+}
+
 func normalizeSlug(slug string) string {
 	slug = strings.TrimPrefix(slug, "/film/")
 	slug = strings.TrimSuffix(slug, "/")
@@ -53,3 +68,33 @@ func ParseListArgs(args []string) ([]*ListID, error) {
 	}
 	return ret, nil
 }
+
+// parseCount reads a count the way letterboxd shows it, like '1,042', '12.4K'
+// or '1.2M'. Anything unreadable is zero
+func parseCount(s string) int {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(s, "K"):
+		multiplier = 1000
+		s = strings.TrimSuffix(s, "K")
+	case strings.HasSuffix(s, "M"):
+		multiplier = 1000000
+		s = strings.TrimSuffix(s, "M")
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return int(f * multiplier)
+}
+
+// paragraphsText returns the text of each paragraph in s, separated by a
+// blank line
+func paragraphsText(s *goquery.Selection) string {
+	var paragraphs []string
+	s.Find("p").Each(func(i int, s *goquery.Selection) {
+		paragraphs = append(paragraphs, strings.TrimSpace(s.Text()))
+	})
+	return strings.Join(paragraphs, "\n\n")
+}
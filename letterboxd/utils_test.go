@@ -74,3 +74,20 @@ func TestParseListArgs(t *testing.T) {
 		}
 	}
 }
+
+func TestParseCount(t *testing.T) {
+	tests := map[string]int{
+		"0":     0,
+		"31":    31,
+		"1,042": 1042,
+		"12.4K": 12400,
+		"98K":   98000,
+		"1.2M":  1200000,
+		"":      0,
+		"nope":  0,
+		" 250 ": 250,
+	}
+	for in, want := range tests {
+		require.Equal(t, want, parseCount(in), in)
+	}
+}
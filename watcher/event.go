@@ -0,0 +1,50 @@
+package watcher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+)
+
+// EventType is the kind of change an Event reports
+type EventType string
+
+const (
+	EventWatchlistAdded EventType = "film_added_to_watchlist"
+	EventFilmLogged     EventType = "film_logged"
+	EventListChanged    EventType = "list_changed"
+)
+
+// EventTypes returns every event type the watcher sends
+func EventTypes() []EventType {
+	return []EventType{EventWatchlistAdded, EventFilmLogged, EventListChanged}
+}
+
+// Event is a change the watcher noticed, as posted to webhooks
+type Event struct {
+	ID    string                 `json:"id"` // Same for the same change, so receivers can drop duplicates
+	Type  EventType              `json:"type"`
+	User  string                 `json:"user"` // Owner of the watchlist, diary or list
+	Time  time.Time              `json:"time"` // When the change was noticed
+	Film  *letterboxd.Film       `json:"film,omitempty"`
+	Entry *letterboxd.DiaryEntry `json:"entry,omitempty"` // Only for film_logged
+	List  *ListChange            `json:"list,omitempty"`  // Only for list_changed
+}
+
+// ListChange is what changed on a list between two polls
+type ListChange struct {
+	List    *letterboxd.List   `json:"list"` // Without its entries
+	Added   []*letterboxd.Film `json:"added"`
+	Removed []*letterboxd.Film `json:"removed"`
+}
+
+// eventID hashes the parts that make a change unique
+func eventID(t EventType, user string, keys ...string) string {
+	sort.Strings(keys)
+	sum := sha256.Sum256([]byte(strings.Join(append([]string{string(t), user}, keys...), "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,386 @@
+/*
+Package watcher polls letterboxd users and lists for changes, and posts each
+change as an event to webhooks
+*/
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/drewstinnett/letterrestd/letterboxd"
+)
+
+// DefaultInterval is how often the watcher polls, unless told otherwise
+const DefaultInterval = 15 * time.Minute
+
+var userRegex = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Config is what the watcher watches, and who it tells about changes
+type Config struct {
+	Interval time.Duration        `json:"interval" yaml:"interval"` // Time between polls. Defaults to DefaultInterval
+	Users    []string             `json:"users" yaml:"users"`       // Users whose watchlists and diaries are watched
+	Lists    []*letterboxd.ListID `json:"lists" yaml:"lists"`
+	Webhooks []*Webhook           `json:"webhooks" yaml:"webhooks"`
+	Attempts int                  `json:"attempts" yaml:"attempts"` // Times to try each webhook call. Defaults to DefaultAttempts
+}
+
+// Validate makes sure there's something to watch, and somewhere to send events
+func (c *Config) Validate() error {
+	if c.Interval < 0 {
+		return errors.New("Interval must be positive")
+	}
+	if c.Attempts < 0 {
+		return errors.New("Attempts must be positive")
+	}
+	if len(c.Users) == 0 && len(c.Lists) == 0 {
+		return errors.New("nothing to watch, add some users or lists")
+	}
+	for _, user := range c.Users {
+		if !userRegex.MatchString(user) {
+			return fmt.Errorf("invalid user: %q", user)
+		}
+	}
+	for _, list := range c.Lists {
+		if err := list.Validate(); err != nil {
+			return err
+		}
+	}
+	if len(c.Webhooks) == 0 {
+		return errors.New("at least one webhook is required")
+	}
+	for _, hook := range c.Webhooks {
+		u, err := url.Parse(hook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid webhook url: %q", hook.URL)
+		}
+		if hook.Secret == "" {
+			return fmt.Errorf("webhook %v is missing a secret", hook.URL)
+		}
+		for _, t := range hook.Events {
+			if !eventTypeKnown(t) {
+				return fmt.Errorf("webhook %v: unknown event %q, must be one of %v", hook.URL, t, EventTypes())
+			}
+		}
+	}
+	return nil
+}
+
+func eventTypeKnown(t EventType) bool {
+	for _, known := range EventTypes() {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Watcher polls for changes and posts them to webhooks. The first poll of
+// each watchlist, diary and list only records what's there, so events are
+// only sent for changes made after the watcher starts
+type Watcher struct {
+	users     letterboxd.UserService
+	lists     letterboxd.ListService
+	films     letterboxd.FilmService
+	config    Config
+	client    *http.Client // For webhook calls
+	baseDelay time.Duration
+	now       func() time.Time
+	seen      map[string]map[string]bool // Keys seen on the last poll of each source
+	seenLists map[string]*letterboxd.List
+}
+
+// New returns a watcher that scrapes with client. hc is used for webhook
+// calls, and may be nil
+func New(client *letterboxd.ScrapeClient, hc *http.Client, config *Config) (*Watcher, error) {
+	if config == nil {
+		return nil, errors.New("watcher config is required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if hc == nil {
+		hc = &http.Client{Timeout: 30 * time.Second}
+	}
+	w := &Watcher{
+		users:     client.User,
+		lists:     client.List,
+		films:     client.Film,
+		config:    *config,
+		client:    hc,
+		baseDelay: defaultBaseDelay,
+		now:       time.Now,
+		seen:      map[string]map[string]bool{},
+		seenLists: map[string]*letterboxd.List{},
+	}
+	if w.config.Interval == 0 {
+		w.config.Interval = DefaultInterval
+	}
+	if w.config.Attempts == 0 {
+		w.config.Attempts = DefaultAttempts
+	}
+	return w, nil
+}
+
+// Run polls and delivers events every Interval until ctx is cancelled
+func (w *Watcher) Run(ctx context.Context) error {
+	log.WithFields(log.Fields{
+		"users":    len(w.config.Users),
+		"lists":    len(w.config.Lists),
+		"interval": w.config.Interval,
+	}).Info("Starting watcher")
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+	for {
+		events, err := w.Poll(ctx)
+		if err == nil {
+			err = w.Deliver(ctx, events)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			log.WithError(err).Warn("Failed to deliver some events")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// wants reports if any webhook wants events of type t, so sources nobody
+// cares about aren't scraped
+func (w *Watcher) wants(t EventType) bool {
+	for _, hook := range w.config.Webhooks {
+		if hook.Wants(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Poll checks every source once, and returns the changes since the last poll.
+// A source that fails to scrape is logged and skipped until the next poll.
+// Sources are compared using just the film previews on their pages, and only
+// the films in events get their details looked up
+func (w *Watcher) Poll(ctx context.Context) ([]*Event, error) {
+	events := []*Event{}
+	now := w.now()
+	for _, user := range w.config.Users {
+		if w.wants(EventWatchlistAdded) {
+			films, err := w.films.PreviewPages(ctx, user+"/watchlist")
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				log.WithError(err).WithField("user", user).Warn("Failed to poll watchlist")
+			} else {
+				added := w.watchlistEvents(user, films, now)
+				if err := w.enhance(ctx, added); err != nil {
+					return nil, err
+				}
+				events = append(events, added...)
+			}
+		}
+		if w.wants(EventFilmLogged) {
+			entries, err := w.recentDiary(ctx, user, now)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				log.WithError(err).WithField("user", user).Warn("Failed to poll diary")
+			} else {
+				events = append(events, w.diaryEvents(user, entries, now)...)
+			}
+		}
+	}
+	if w.wants(EventListChanged) {
+		for _, id := range w.config.Lists {
+			list, err := w.lists.ListFilms(ctx, &letterboxd.ListFilmsOpt{User: id.User, Slug: id.Slug, LastPage: -1, Previews: true})
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				log.WithError(err).WithField("list", id.String()).Warn("Failed to poll list")
+				continue
+			}
+			if event := w.listEvent(id, list, now); event != nil {
+				if err := w.films.EnhanceFilmList(ctx, &event.List.Added); err != nil {
+					return nil, err
+				}
+				events = append(events, event)
+			}
+		}
+	}
+	return events, nil
+}
+
+// enhance looks up the details of the films in events
+func (w *Watcher) enhance(ctx context.Context, events []*Event) error {
+	films := make([]*letterboxd.Film, 0, len(events))
+	for _, event := range events {
+		films = append(films, event.Film)
+	}
+	return w.films.EnhanceFilmList(ctx, &films)
+}
+
+// Deliver posts each event to the webhooks that want it. Every event is tried,
+// and the first failure is returned
+func (w *Watcher) Deliver(ctx context.Context, events []*Event) error {
+	var firstErr error
+	for _, event := range events {
+		for _, hook := range w.config.Webhooks {
+			if !hook.Wants(event.Type) {
+				continue
+			}
+			if err := w.deliver(ctx, hook, event); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				log.WithError(err).WithFields(log.Fields{
+					"url":   hook.URL,
+					"event": event.ID,
+				}).Warn("Failed to deliver event")
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+	}
+	return firstErr
+}
+
+// recentDiary returns this year's diary entries, along with last year's in
+// January so entries logged over the new year aren't missed
+func (w *Watcher) recentDiary(ctx context.Context, user string, now time.Time) ([]*letterboxd.DiaryEntry, error) {
+	entries, err := w.users.Diary(ctx, user, &letterboxd.DiaryOpt{Year: now.Year()})
+	if err != nil {
+		return nil, err
+	}
+	if now.Month() == time.January {
+		last, err := w.users.Diary(ctx, user, &letterboxd.DiaryOpt{Year: now.Year() - 1})
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, last...)
+	}
+	return entries, nil
+}
+
+// remember records keys as what source has now, and returns the keys that are
+// new since the last poll. Nothing is new on the first poll of a source
+func (w *Watcher) remember(source string, keys []string) map[string]bool {
+	current := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		current[key] = true
+	}
+	last, ok := w.seen[source]
+	w.seen[source] = current
+	added := map[string]bool{}
+	if !ok {
+		return added
+	}
+	for key := range current {
+		if !last[key] {
+			added[key] = true
+		}
+	}
+	return added
+}
+
+func (w *Watcher) watchlistEvents(user string, films []*letterboxd.Film, now time.Time) []*Event {
+	keys := make([]string, 0, len(films))
+	for _, film := range films {
+		keys = append(keys, film.Slug)
+	}
+	added := w.remember("watchlist:"+user, keys)
+	events := []*Event{}
+	for _, film := range films {
+		if !added[film.Slug] {
+			continue
+		}
+		// Pages can overlap if the watchlist changes while it's scraped
+		delete(added, film.Slug)
+		events = append(events, &Event{
+			ID:   eventID(EventWatchlistAdded, user, film.Slug),
+			Type: EventWatchlistAdded,
+			User: user,
+			Time: now,
+			Film: film,
+		})
+	}
+	return events
+}
+
+func diaryKey(entry *letterboxd.DiaryEntry) string {
+	return fmt.Sprintf("%s/%s", entry.Film.Slug, entry.WatchedDate.Format("2006-01-02"))
+}
+
+func (w *Watcher) diaryEvents(user string, entries []*letterboxd.DiaryEntry, now time.Time) []*Event {
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		keys = append(keys, diaryKey(entry))
+	}
+	added := w.remember("diary:"+user, keys)
+	events := []*Event{}
+	for _, entry := range entries {
+		key := diaryKey(entry)
+		if !added[key] {
+			continue
+		}
+		// Only send one event if the same film shows up twice on a day
+		delete(added, key)
+		events = append(events, &Event{
+			ID:    eventID(EventFilmLogged, user, key),
+			Type:  EventFilmLogged,
+			User:  user,
+			Time:  now,
+			Film:  entry.Film,
+			Entry: entry,
+		})
+	}
+	return events
+}
+
+// listEvent compares a list with the last poll, returning nil if its films,
+// title and description are all the same
+func (w *Watcher) listEvent(id *letterboxd.ListID, list *letterboxd.List, now time.Time) *Event {
+	last, ok := w.seenLists[id.String()]
+	w.seenLists[id.String()] = list
+	if !ok {
+		return nil
+	}
+	change := &ListChange{
+		Added:   letterboxd.FilmsNotIn(list.Films(), last.Films()),
+		Removed: letterboxd.FilmsNotIn(last.Films(), list.Films()),
+	}
+	if len(change.Added) == 0 && len(change.Removed) == 0 && list.Title == last.Title && list.Description == last.Description {
+		return nil
+	}
+	summary := *list
+	summary.Entries = nil
+	change.List = &summary
+
+	keys := []string{list.Title, list.Description}
+	for _, film := range change.Added {
+		keys = append(keys, "+"+film.Slug)
+	}
+	for _, film := range change.Removed {
+		keys = append(keys, "-"+film.Slug)
+	}
+	return &Event{
+		ID:   eventID(EventListChanged, id.User, append(keys, id.Slug)...),
+		Type: EventListChanged,
+		User: id.User,
+		Time: now,
+		List: change,
+	}
+}
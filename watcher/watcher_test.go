@@ -0,0 +1,258 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	firstPoster    = regexp.MustCompile(`(?s)<li class="poster-container".*?</li>`)
+	firstDiaryRow  = regexp.MustCompile(`(?s)<tr class="diary-entry-row.*?</tr>`)
+	firstListEntry = regexp.MustCompile(`(?s)<li class="film-detail">.*?</li>`)
+	pagination     = regexp.MustCompile(`<div class="pagination">.*`)
+)
+
+// newLetterboxdServer serves someguy's watchlist and diary, and dave's list,
+// from the letterboxd package's fixtures. Until changed is set, each is
+// missing its first film. Requests for film pages are counted in filmRequests
+func newLetterboxdServer(t *testing.T, changed, filmRequests *int32) *httptest.Server {
+	read := func(name string) string {
+		b, err := os.ReadFile("../letterboxd/testdata/" + name)
+		require.NoError(t, err)
+		return string(b)
+	}
+	// The watchlist links to pages that aren't in the fixtures
+	watchlist := pagination.ReplaceAllString(read("user/watchlist-single-page.html"), "")
+	diary, list, film := read("user/diary/2.html"), read("list/top250-detail.html"), read("film/sweetback.html")
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := atomic.LoadInt32(changed) == 1
+		var page string
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/someguy/watchlist/"):
+			page = watchlist
+			if !after {
+				page = firstPoster.ReplaceAllStringFunc(page, onlyFirst())
+			}
+		case strings.HasPrefix(r.URL.Path, "/someguy/films/diary/"):
+			page = diary
+			if !after {
+				page = firstDiaryRow.ReplaceAllStringFunc(page, onlyFirst())
+			}
+		case strings.HasPrefix(r.URL.Path, "/dave/list/official-top-250-narrative-feature-films/"):
+			page = list
+			if !after {
+				page = firstListEntry.ReplaceAllStringFunc(page, onlyFirst())
+			}
+		case strings.HasPrefix(r.URL.Path, "/film/"):
+			atomic.AddInt32(filmRequests, 1)
+			page = film
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, page)
+	}))
+}
+
+// onlyFirst returns a replacer that removes the first match, and keeps the rest
+func onlyFirst() func(string) string {
+	done := false
+	return func(s string) string {
+		if done {
+			return s
+		}
+		done = true
+		return ""
+	}
+}
+
+// hookReceiver records the events posted to it, failing the first call
+type hookReceiver struct {
+	mu     sync.Mutex
+	calls  int
+	events []*Event
+}
+
+func (h *hookReceiver) handler(t *testing.T, secret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.calls++
+		if h.calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if !Verify(secret, body, r.Header.Get(SignatureHeader)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		event := &Event{}
+		require.NoError(t, json.Unmarshal(body, event))
+		require.Equal(t, string(event.Type), r.Header.Get(EventHeader))
+		require.Equal(t, event.ID, r.Header.Get(DeliveryHeader))
+		h.events = append(h.events, event)
+	}
+}
+
+func newTestWatcher(t *testing.T, baseURL string, config *Config) *Watcher {
+	client := letterboxd.NewScrapeClient(nil, &letterboxd.ClientOptions{Rate: -1, Concurrency: -1})
+	client.BaseURL = baseURL
+	w, err := New(client, nil, config)
+	require.NoError(t, err)
+	w.baseDelay = time.Millisecond
+	w.now = func() time.Time { return time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC) }
+	return w
+}
+
+func TestWatcher(t *testing.T) {
+	var changed, filmRequests int32
+	lsrv := newLetterboxdServer(t, &changed, &filmRequests)
+	defer lsrv.Close()
+	receiver := &hookReceiver{}
+	hsrv := httptest.NewServer(receiver.handler(t, "sekrit"))
+	defer hsrv.Close()
+
+	w := newTestWatcher(t, lsrv.URL, &Config{
+		Users:    []string{"someguy"},
+		Lists:    []*letterboxd.ListID{{User: "dave", Slug: "official-top-250-narrative-feature-films"}},
+		Webhooks: []*Webhook{{URL: hsrv.URL, Secret: "sekrit"}},
+	})
+
+	// The first poll only takes note of what's there
+	events, err := w.Poll(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, events)
+	require.Zero(t, atomic.LoadInt32(&filmRequests))
+
+	atomic.StoreInt32(&changed, 1)
+	events, err = w.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	require.Equal(t, EventWatchlistAdded, events[0].Type)
+	require.Equal(t, "someguy", events[0].User)
+	require.Equal(t, "the-stendhal-syndrome", events[0].Film.Slug)
+	require.NotEmpty(t, events[0].Film.Genres)
+
+	require.Equal(t, EventFilmLogged, events[1].Type)
+	require.Equal(t, "gremlins", events[1].Film.Slug)
+	require.Equal(t, time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC), events[1].Entry.WatchedDate)
+
+	require.Equal(t, EventListChanged, events[2].Type)
+	require.Equal(t, "dave", events[2].User)
+	require.Len(t, events[2].List.Added, 1)
+	require.Equal(t, "everything-everywhere-all-at-once", events[2].List.Added[0].Slug)
+	require.Empty(t, events[2].List.Removed)
+	require.Empty(t, events[2].List.List.Entries)
+	require.NotEmpty(t, events[2].List.Added[0].Genres)
+	// Only the 2 added films were looked up, with a film and themes page each
+	require.Equal(t, int32(4), atomic.LoadInt32(&filmRequests))
+
+	// Nothing changed since
+	again, err := w.Poll(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, again)
+
+	// The first call fails, and is retried
+	require.NoError(t, w.Deliver(context.Background(), events))
+	require.Equal(t, 4, receiver.calls)
+	require.Len(t, receiver.events, 3)
+	require.Equal(t, events[0].ID, receiver.events[0].ID)
+}
+
+func TestWatcherOnlyPollsWantedSources(t *testing.T) {
+	var changed, filmRequests int32
+	var requests []string
+	var mu sync.Mutex
+	lsrv := newLetterboxdServer(t, &changed, &filmRequests)
+	defer lsrv.Close()
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path)
+		mu.Unlock()
+		lsrv.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+
+	w := newTestWatcher(t, proxy.URL, &Config{
+		Users:    []string{"someguy"},
+		Webhooks: []*Webhook{{URL: "http://127.0.0.1:0/", Secret: "sekrit", Events: []EventType{EventFilmLogged}}},
+	})
+	_, err := w.Poll(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"/someguy/films/diary/for/2022/page/1/"}, requests)
+}
+
+func TestDeliverGivesUp(t *testing.T) {
+	var calls int32
+	status := int32(http.StatusInternalServerError)
+	hsrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer hsrv.Close()
+	w := newTestWatcher(t, "http://127.0.0.1:0", &Config{
+		Users:    []string{"someguy"},
+		Webhooks: []*Webhook{{URL: hsrv.URL, Secret: "sekrit"}},
+		Attempts: 3,
+	})
+	err := w.Deliver(context.Background(), []*Event{{ID: "1", Type: EventFilmLogged}})
+	require.Error(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	// Client errors aren't worth retrying
+	atomic.StoreInt32(&calls, 0)
+	atomic.StoreInt32(&status, http.StatusBadRequest)
+	err = w.Deliver(context.Background(), []*Event{{ID: "1", Type: EventFilmLogged}})
+	require.Error(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	sig := Sign("sekrit", body)
+	require.True(t, strings.HasPrefix(sig, "sha256="))
+	require.True(t, Verify("sekrit", body, sig))
+	require.False(t, Verify("other", body, sig))
+	require.False(t, Verify("sekrit", []byte(`{"id":"2"}`), sig))
+}
+
+func TestConfigValidate(t *testing.T) {
+	hooks := []*Webhook{{URL: "https://example.com/hook", Secret: "sekrit"}}
+	tests := map[string]struct {
+		config  Config
+		wantErr bool
+	}{
+		"valid":         {config: Config{Users: []string{"someguy"}, Webhooks: hooks}},
+		"nothing":       {config: Config{Webhooks: hooks}, wantErr: true},
+		"no-hooks":      {config: Config{Users: []string{"someguy"}}, wantErr: true},
+		"bad-user":      {config: Config{Users: []string{"some/guy"}, Webhooks: hooks}, wantErr: true},
+		"bad-list":      {config: Config{Lists: []*letterboxd.ListID{{User: "dave", Slug: "Not A Slug"}}, Webhooks: hooks}, wantErr: true},
+		"no-secret":     {config: Config{Users: []string{"someguy"}, Webhooks: []*Webhook{{URL: "https://example.com/hook"}}}, wantErr: true},
+		"bad-url":       {config: Config{Users: []string{"someguy"}, Webhooks: []*Webhook{{URL: "example.com", Secret: "sekrit"}}}, wantErr: true},
+		"unknown-event": {config: Config{Users: []string{"someguy"}, Webhooks: []*Webhook{{URL: "https://example.com/hook", Secret: "sekrit", Events: []EventType{"film_liked"}}}}, wantErr: true},
+	}
+	for name, tt := range tests {
+		err := tt.config.Validate()
+		if tt.wantErr {
+			require.Error(t, err, name)
+		} else {
+			require.NoError(t, err, name)
+		}
+	}
+}
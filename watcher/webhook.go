@@ -0,0 +1,118 @@
+package watcher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/drewstinnett/letterrestd/letterboxd"
+)
+
+const (
+	// SignatureHeader holds the HMAC-SHA256 of the request body, like
+	// 'sha256=<hex>'
+	SignatureHeader = "X-Letterrestd-Signature"
+	EventHeader     = "X-Letterrestd-Event"
+	DeliveryHeader  = "X-Letterrestd-Delivery"
+
+	// DefaultAttempts is how many times a webhook call is tried, including the
+	// first attempt
+	DefaultAttempts  = 5
+	defaultBaseDelay = time.Second
+	defaultMaxDelay  = time.Minute
+)
+
+// Webhook is somewhere events are posted to
+type Webhook struct {
+	URL    string      `json:"url" yaml:"url"`
+	Secret string      `json:"secret" yaml:"secret"`                     // Key for the HMAC signature
+	Events []EventType `json:"events,omitempty" yaml:"events,omitempty"` // Events to send. Sends every event if empty
+}
+
+// Wants reports if the webhook should get events of type t
+func (h *Webhook) Wants(t EventType) bool {
+	if len(h.Events) == 0 {
+		return true
+	}
+	for _, e := range h.Events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign returns the signature header value for body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports if signature is a valid signature of body. Receivers can use
+// this to check a webhook call came from us
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// deliver posts event to hook, retrying with jittered exponential backoff when
+// the call fails with a network error, a 429 or a 5xx
+func (w *Watcher) deliver(ctx context.Context, hook *Webhook, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	for attempt := 1; ; attempt++ {
+		retry, err := w.post(ctx, hook, event, body)
+		if err == nil || !retry || attempt >= w.config.Attempts {
+			return err
+		}
+		delay := letterboxd.Backoff(attempt, w.baseDelay, defaultMaxDelay)
+		log.WithError(err).WithFields(log.Fields{
+			"url":     hook.URL,
+			"event":   event.ID,
+			"attempt": attempt,
+			"delay":   delay,
+		}).Debug("Retrying webhook")
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// post makes a single webhook call. retry is true if the call is worth trying
+// again
+func (w *Watcher) post(ctx context.Context, hook *Webhook, event *Event, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "letterrestd-watcher")
+	req.Header.Set(EventHeader, string(event.Type))
+	req.Header.Set(DeliveryHeader, event.ID)
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, body))
+	res, err := w.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body) // nolint:errcheck
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("webhook %v returned %v", hook.URL, res.Status)
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500, err
+}
@@ -1,7 +1,13 @@
 package v1
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/gin-gonic/gin"
 )
 
 type APIResponse struct {
@@ -16,3 +22,43 @@ func GetFilm(c *gin.Context) {
 	})
 }
 */
+
+// queryInt returns an integer query parameter, or 0 if it was not given
+func queryInt(c *gin.Context, key string) (int, error) {
+	v := c.Query(key)
+	if v == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%v must be a number", key)
+	}
+	return i, nil
+}
+
+// queryPage returns the page asked for, or 0 for the default if there isn't
+// one. Pages start at 1
+func queryPage(c *gin.Context) (int, error) {
+	page, err := queryInt(c, "page")
+	if err != nil {
+		return 0, err
+	}
+	if c.Query("page") != "" && page < 1 {
+		return 0, errors.New("page must be 1 or more")
+	}
+	return page, nil
+}
+
+// queryUsers returns the usernames in a query parameter, which may be comma
+// separated, repeated, or both
+func queryUsers(c *gin.Context, key string) []string {
+	var users []string
+	for _, v := range c.QueryArray(key) {
+		for _, user := range strings.Split(v, ",") {
+			if user = strings.TrimSpace(user); user != "" {
+				users = append(users, user)
+			}
+		}
+	}
+	return users
+}
@@ -0,0 +1,18 @@
+package v1_test
+
+import (
+	"net/http"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+)
+
+// newTestScrapeClient returns a client for a test server, without the
+// politeness limits meant for letterboxd.com
+func newTestScrapeClient(baseURL string) *letterboxd.ScrapeClient {
+	sc := letterboxd.NewScrapeClient(http.DefaultClient, &letterboxd.ClientOptions{
+		Rate:        -1,
+		Concurrency: -1,
+	})
+	sc.BaseURL = baseURL
+	return sc
+}
@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/gin-gonic/gin"
+)
+
+// @BasePath /api/v1
+
+// GetCollection godoc
+// @Summary Get a collection
+// @Schemes
+// @Description Get the films in a collection, like the Halloween films, in release order
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param slug path string true "Collection slug, like 'halloween-collection'"
+// @Success 200 {object} APIResponse
+// @Router /collections/{slug} [get]
+func GetCollection(c *gin.Context) {
+	slug := c.Param("slug")
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	collection, err := sc.Film.Collection(c.Request.Context(), slug)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: collection,
+	})
+}
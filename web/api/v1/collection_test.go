@@ -0,0 +1,61 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/drewstinnett/letterrestd/web"
+	v1 "github.com/drewstinnett/letterrestd/web/api/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCollection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fixture string
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/films/in/halloween-collection/"):
+			fixture = "testdata/collection/halloween-collection.html"
+		case strings.HasPrefix(r.URL.Path, "/film/"):
+			fixture = "testdata/film/sweetback.html"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open(fixture)
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	r := gin.Default()
+	sc := newTestScrapeClient(srv.URL)
+	r.Use(web.APIClient(sc))
+	r.GET("/collections/:slug", v1.GetCollection)
+
+	req, err := http.NewRequest(http.MethodGet, "/collections/halloween-collection", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ar v1.APIResponse
+	err = json.Unmarshal(w.Body.Bytes(), &ar)
+	require.NoError(t, err)
+	collection := ar.Data.(map[string]interface{})
+	require.Equal(t, "Halloween Collection", collection["name"])
+	require.Equal(t, 4, len(collection["films"].([]interface{})))
+
+	req, err = http.NewRequest(http.MethodGet, "/collections/never-collection", nil)
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
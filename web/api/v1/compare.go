@@ -0,0 +1,47 @@
+package v1
+
+import (
+	"github.com/drewstinnett/letterrestd/analysis"
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/gin-gonic/gin"
+)
+
+// @BasePath /api/v1
+
+// Compare godoc
+// @Summary Compare the films of two or more users
+// @Schemes
+// @Description Films every user has watched, films on one user's watchlist that others have watched, and a taste compatibility score for each pair of users. Every user's full watched history is scraped, so this can be slow
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param users query string true "Comma separated usernames, like 'alice,bob'"
+// @Param min_shared_ratings query int false "Films a pair must have both rated to get a compatibility score. Defaults to 5"
+// @Success 200 {object} APIResponse
+// @Router /compare [get]
+func Compare(c *gin.Context) {
+	users := queryUsers(c, "users")
+	if err := analysis.ValidateUsers(users); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	opt := &analysis.CompareOpt{}
+	var err error
+	if opt.MinSharedRatings, err = queryInt(c, "min_shared_ratings"); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	if err = opt.Validate(); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	comparison, err := analysis.CompareUsers(c.Request.Context(), &sc, users, opt)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: comparison,
+	})
+}
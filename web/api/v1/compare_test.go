@@ -0,0 +1,81 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/drewstinnett/letterrestd/web"
+	v1 "github.com/drewstinnett/letterrestd/web/api/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		var fixture string
+		switch {
+		case len(parts) > 1 && parts[0] == "film":
+			fixture = "testdata/film/sweetback.html"
+		case len(parts) > 1 && (parts[0] == "alice" || parts[0] == "bob") && parts[1] == "films":
+			fixture = fmt.Sprintf("testdata/compare/%v-watched.html", parts[0])
+		case len(parts) > 1 && (parts[0] == "alice" || parts[0] == "bob") && parts[1] == "watchlist":
+			fixture = fmt.Sprintf("testdata/compare/%v-watchlist.html", parts[0])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open(fixture)
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	r := gin.Default()
+	sc := newTestScrapeClient(srv.URL)
+	r.Use(web.APIClient(sc))
+	r.GET("/compare", v1.Compare)
+
+	req, err := http.NewRequest(http.MethodGet, "/compare?users=alice,bob", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ar v1.APIResponse
+	err = json.Unmarshal(w.Body.Bytes(), &ar)
+	require.NoError(t, err)
+	data := ar.Data.(map[string]interface{})
+	require.Equal(t, []interface{}{"alice", "bob"}, data["users"])
+	require.Equal(t, 5, len(data["watched_by_all"].([]interface{})))
+	matches := data["watchlist_watched"].([]interface{})
+	require.Equal(t, 1, len(matches))
+	match := matches[0].(map[string]interface{})
+	require.Equal(t, "jaws", match["film"].(map[string]interface{})["slug"])
+	require.Equal(t, map[string]interface{}{"bob": float64(4)}, match["ratings"])
+	compat := data["compatibility"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, float64(5), compat["shared_rated"])
+	require.NotNil(t, compat["score"])
+
+	for _, q := range []string{"users=alice", "users=alice,alice", "users=alice,bob&min_shared_ratings=x"} {
+		req, err = http.NewRequest(http.MethodGet, "/compare?"+q, nil)
+		require.NoError(t, err)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, q)
+	}
+
+	req, err = http.NewRequest(http.MethodGet, "/compare?users=alice&users=nobody", nil)
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorStatus maps an error from the scrape client to the HTTP status code
+// the API should answer with
+func ErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, letterboxd.ErrInvalidID):
+		return http.StatusBadRequest
+	case errors.Is(err, letterboxd.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, letterboxd.ErrPrivateProfile):
+		return http.StatusForbidden
+	case errors.Is(err, letterboxd.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, letterboxd.ErrParse), errors.Is(err, letterboxd.ErrUpstream):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// abortWithError responds with the status that matches err, passing along
+// Retry-After when letterboxd asked us to back off
+func abortWithError(c *gin.Context, err error) {
+	var rerr *letterboxd.RateLimitError
+	if errors.As(err, &rerr) && rerr.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(rerr.RetryAfter.Seconds()))))
+	}
+	c.AbortWithStatusJSON(ErrorStatus(err), gin.H{
+		"message": err.Error(),
+	})
+}
+
+// abortWithBadRequest responds with a 400 for a request we can't make sense of
+func abortWithBadRequest(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+		"message": err.Error(),
+	})
+}
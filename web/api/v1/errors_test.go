@@ -0,0 +1,60 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/drewstinnett/letterrestd/web"
+	v1 "github.com/drewstinnett/letterrestd/web/api/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/film/missing"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasPrefix(r.URL.Path, "/film/busy"):
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case strings.HasPrefix(r.URL.Path, "/film/down"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte("<html></html>"))
+		}
+	}))
+	defer srv.Close()
+
+	r := gin.Default()
+	sc := letterboxd.NewScrapeClient(http.DefaultClient, &letterboxd.ClientOptions{
+		Retry: &letterboxd.RetryOptions{MaxAttempts: 1},
+	})
+	sc.BaseURL = srv.URL
+	r.Use(web.APIClient(sc))
+	r.GET("/film/:slug", v1.GetFilm)
+
+	tests := []struct {
+		slug string
+		want int
+	}{
+		{"missing", http.StatusNotFound},
+		{"busy", http.StatusTooManyRequests},
+		{"down", http.StatusBadGateway},
+		{"garbage", http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodGet, "/film/"+tt.slug, nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, tt.want, w.Code, tt.slug)
+		if tt.want == http.StatusTooManyRequests {
+			require.Equal(t, "30", w.Header().Get("Retry-After"))
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package v1
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/apex/log"
 	"github.com/drewstinnett/letterrestd/letterboxd"
 	"github.com/gin-gonic/gin"
@@ -19,17 +22,148 @@ import (
 func GetFilm(c *gin.Context) {
 	slug := c.Param("slug")
 	sc := c.MustGet("client").(letterboxd.ScrapeClient)
-	film, err := sc.Film.Get(nil, slug)
+	film, err := sc.Film.Get(c.Request.Context(), slug)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"slug": slug,
 		}).WithError(err).Warn("Error getting film")
-		c.JSON(404, gin.H{
-			"message": err.Error(),
-		})
+		abortWithError(c, err)
 		return
 	}
 	c.IndentedJSON(200, APIResponse{
 		Data: film,
 	})
 }
+
+// GetFilmCredits godoc
+// @Summary Get film credits
+// @Schemes
+// @Description Get the cast and crew for a film from a film slug
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param slug path string true "Film slug"
+// @Success 200 {object} APIResponse
+// @Router /films/{slug}/credits [get]
+func GetFilmCredits(c *gin.Context) {
+	slug := c.Param("slug")
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	credits, err := sc.Film.Credits(c.Request.Context(), slug)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"slug": slug,
+		}).WithError(err).Warn("Error getting film credits")
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: credits,
+	})
+}
+
+// GetFilmByIMDB godoc
+// @Summary Get a film by IMDb ID
+// @Schemes
+// @Description Get a film from its IMDb ID
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param id path string true "IMDb ID, like 'tt0084787'"
+// @Success 200 {object} APIResponse
+// @Router /films/imdb/{id} [get]
+func GetFilmByIMDB(c *gin.Context) {
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	film, err := sc.Film.GetByIMDB(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: film,
+	})
+}
+
+// GetFilmByTMDB godoc
+// @Summary Get a film by TMDb ID
+// @Schemes
+// @Description Get a film from its TMDb movie ID
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param id path string true "TMDb movie ID, like '1091'"
+// @Success 200 {object} APIResponse
+// @Router /films/tmdb/{id} [get]
+func GetFilmByTMDB(c *gin.Context) {
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	film, err := sc.Film.GetByTMDB(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: film,
+	})
+}
+
+// BrowseFilms godoc
+// @Summary Browse films
+// @Schemes
+// @Description Get a page of films matching a set of filters, like letterboxd's own browse pages
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param genre query []string false "Films must be in every one of these genres, like 'horror'" collectionFormat(multi)
+// @Param exclude_genre query []string false "Films must not be in any of these genres" collectionFormat(multi)
+// @Param decade query int false "Decade, like 1980. Can't be used with year"
+// @Param year query int false "Release year. Can't be used with decade"
+// @Param country query string false "Country, like 'usa'"
+// @Param language query string false "Language, like 'english'"
+// @Param service query string false "Streaming service, like 'netflix-us'"
+// @Param sort query string false "Sort order, like 'rating' or 'release-earliest'"
+// @Param min_rating query number false "Only films with at least this average rating"
+// @Param page query int false "Page of films to fetch. Defaults to 1"
+// @Success 200 {object} APIResponse
+// @Router /films [get]
+func BrowseFilms(c *gin.Context) {
+	opt := &letterboxd.BrowseOpt{
+		Genres:        c.QueryArray("genre"),
+		ExcludeGenres: c.QueryArray("exclude_genre"),
+		Country:       c.Query("country"),
+		Language:      c.Query("language"),
+		Service:       c.Query("service"),
+		SortBy:        c.Query("sort"),
+	}
+	var err error
+	if opt.Decade, err = queryInt(c, "decade"); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	if opt.Year, err = queryInt(c, "year"); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	if opt.FirstPage, err = queryPage(c); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	if v := c.Query("min_rating"); v != "" {
+		if opt.MinRating, err = strconv.ParseFloat(v, 64); err != nil {
+			abortWithBadRequest(c, fmt.Errorf("min_rating must be a number"))
+			return
+		}
+	}
+	if err = opt.Validate(); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	films, pagination, err := sc.Film.Browse(c.Request.Context(), opt)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data:       films,
+		Pagination: pagination,
+	})
+}
@@ -11,7 +11,6 @@ import (
 	"testing"
 
 	"github.com/apex/log"
-	"github.com/drewstinnett/letterrestd/letterboxd"
 	"github.com/drewstinnett/letterrestd/web"
 	v1 "github.com/drewstinnett/letterrestd/web/api/v1"
 	"github.com/gin-gonic/gin"
@@ -38,8 +37,7 @@ func TestGetFilm(t *testing.T) {
 	defer srv.Close()
 
 	r := gin.Default()
-	sc := letterboxd.NewScrapeClient(http.DefaultClient)
-	sc.BaseURL = srv.URL
+	sc := newTestScrapeClient(srv.URL)
 	r.Use(web.APIClient(sc))
 	r.GET("/film/:id", v1.GetFilm)
 
@@ -64,3 +62,131 @@ func TestGetFilm(t *testing.T) {
 	// require.NotNil(t, f)
 	// require.Equal(t, "foo", f)
 }
+
+func TestGetFilmCredits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/film/") {
+			r, err := os.Open("testdata/film/sweetback.html")
+			require.NoError(t, err)
+			defer r.Close()
+			_, err = io.Copy(w, r)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	r := gin.Default()
+	sc := newTestScrapeClient(srv.URL)
+	r.Use(web.APIClient(sc))
+	r.GET("/films/:slug/credits", v1.GetFilmCredits)
+
+	req, err := http.NewRequest(http.MethodGet, "/films/sweetback/credits", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ar v1.APIResponse
+	err = json.Unmarshal(w.Body.Bytes(), &ar)
+	require.NoError(t, err)
+	credits := ar.Data.(map[string]interface{})
+	cast := credits["cast"].([]interface{})
+	require.Equal(t, "Simon Chuckster", cast[0].(map[string]interface{})["name"])
+	require.NotEmpty(t, credits["crew"])
+}
+
+func TestBrowseFilms(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fixture string
+		switch {
+		case r.URL.Path == "/films/genre/horror/decade/1980s/by/rating/page/2/":
+			fixture = "testdata/browse/2.html"
+		case strings.HasPrefix(r.URL.Path, "/film/"):
+			fixture = "testdata/film/sweetback.html"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open(fixture)
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	r := gin.Default()
+	sc := newTestScrapeClient(srv.URL)
+	r.Use(web.APIClient(sc))
+	r.GET("/films", v1.BrowseFilms)
+
+	req, err := http.NewRequest(http.MethodGet, "/films?genre=horror&decade=1980&sort=rating&page=2", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ar v1.APIResponse
+	err = json.Unmarshal(w.Body.Bytes(), &ar)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(ar.Data.([]interface{})))
+	require.True(t, ar.Pagination.IsLast)
+
+	for _, query := range []string{"decade=1985", "sort=vibes", "min_rating=lots", "year=1985&decade=1980", "page=-1", "page=0"} {
+		req, err = http.NewRequest(http.MethodGet, "/films?"+query, nil)
+		require.NoError(t, err)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestGetFilmByExternalID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/imdb/tt0067810/", r.URL.Path == "/tmdb/5822/":
+			http.Redirect(w, r, "/film/sweet-sweetbacks-baadasssss-song/", http.StatusFound)
+		case strings.HasPrefix(r.URL.Path, "/film/"):
+			rp, err := os.Open("testdata/film/sweetback.html")
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r := gin.Default()
+	sc := newTestScrapeClient(srv.URL)
+	r.Use(web.APIClient(sc))
+	r.GET("/films/imdb/:id", v1.GetFilmByIMDB)
+	r.GET("/films/tmdb/:id", v1.GetFilmByTMDB)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/films/imdb/tt0067810", http.StatusOK},
+		{"/films/tmdb/5822", http.StatusOK},
+		{"/films/imdb/tt0000001", http.StatusNotFound},
+		{"/films/imdb/sweetback", http.StatusBadRequest},
+		{"/films/tmdb/sweetback", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodGet, tt.path, nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, tt.want, w.Code, tt.path)
+		if tt.want != http.StatusOK {
+			continue
+		}
+		var ar v1.APIResponse
+		err = json.Unmarshal(w.Body.Bytes(), &ar)
+		require.NoError(t, err)
+		require.Equal(t, "sweet-sweetbacks-baadasssss-song", ar.Data.(map[string]interface{})["slug"])
+	}
+}
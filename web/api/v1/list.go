@@ -10,30 +10,82 @@ import (
 // ListExample godoc
 // @Summary Get List Example
 // @Schemes
-// @Description Get a list of films from a user's list
+// @Description Get a user's list, with its metadata and every entry in order
 // @Tags list
 // @Accept json
 // @Produce json
 // @Param user path string true "Username of the list owner"
 // @Param slug path string true "List slug"
+// @Param notes query bool false "Include the note for each entry. This fetches the slower detail view"
 // @Success 200 {object} APIResponse
 // @Router /lists/{user}/{slug} [get]
 func GetList(c *gin.Context) {
 	user := c.Param("user")
 	slug := c.Param("slug")
 	sc := c.MustGet("client").(letterboxd.ScrapeClient)
-	films, err := sc.List.ListFilms(nil, &letterboxd.ListFilmsOpt{
+	list, err := sc.List.ListFilms(c.Request.Context(), &letterboxd.ListFilmsOpt{
 		User:     user,
 		Slug:     slug,
 		LastPage: -1,
+		Notes:    c.Query("notes") == "true",
 	})
 	if err != nil {
-		c.JSON(500, gin.H{
-			"message": err.Error(),
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: list,
+	})
+}
+
+// GetUserLists godoc
+// @Summary Get lists per user
+// @Schemes
+// @Description Get the metadata for every list a user has made
+// @Tags list
+// @Accept json
+// @Produce json
+// @Param user path string true "user"
+// @Success 200 {object} APIResponse
+// @Router /users/{user}/lists [get]
+func GetUserLists(c *gin.Context) {
+	user := c.Param("user")
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	lists, err := sc.List.ByUser(c.Request.Context(), user)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: lists,
+	})
+}
+
+// GetListCatalog godoc
+// @Summary Get the curated list catalog
+// @Schemes
+// @Description Get the curated lists this server knows about, sorted in to groups. Any group can be used with the batch scrape
+// @Tags list
+// @Accept json
+// @Produce json
+// @Param group query string false "Only return this group"
+// @Success 200 {object} APIResponse
+// @Router /lists/catalog [get]
+func GetListCatalog(c *gin.Context) {
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	catalog := sc.List.Catalog(c.Request.Context())
+	if name := c.Query("group"); name != "" {
+		group, err := catalog.Group(name)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.IndentedJSON(200, APIResponse{
+			Data: group,
 		})
 		return
 	}
 	c.IndentedJSON(200, APIResponse{
-		Data: films,
+		Data: catalog,
 	})
 }
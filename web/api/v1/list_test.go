@@ -11,7 +11,6 @@ import (
 	"testing"
 
 	"github.com/apex/log"
-	"github.com/drewstinnett/letterrestd/letterboxd"
 	"github.com/drewstinnett/letterrestd/web"
 	v1 "github.com/drewstinnett/letterrestd/web/api/v1"
 	"github.com/gin-gonic/gin"
@@ -47,8 +46,7 @@ func TestListFilms(t *testing.T) {
 	defer srv.Close()
 
 	r := gin.Default()
-	sc := letterboxd.NewScrapeClient(http.DefaultClient)
-	sc.BaseURL = srv.URL
+	sc := newTestScrapeClient(srv.URL)
 	r.Use(web.APIClient(sc))
 	r.GET("/lists/:user/:slug", v1.GetList)
 
@@ -62,7 +60,12 @@ func TestListFilms(t *testing.T) {
 	resp := &v1.APIResponse{}
 	err = json.Unmarshal(w.Body.Bytes(), &resp)
 	require.NoError(t, err)
-	require.Equal(t, 250, len(resp.Data.([]interface{})))
+	list := resp.Data.(map[string]interface{})
+	require.Equal(t, "Official Top 250 Narrative Feature Films", list["title"])
+	require.Equal(t, true, list["ranked"])
+	entries := list["entries"].([]interface{})
+	require.Equal(t, 250, len(entries))
+	require.Equal(t, float64(250), entries[249].(map[string]interface{})["position"])
 }
 
 func TestListFilmsSinglePage(t *testing.T) {
@@ -93,8 +96,7 @@ func TestListFilmsSinglePage(t *testing.T) {
 	defer srv.Close()
 
 	r := gin.Default()
-	sc := letterboxd.NewScrapeClient(http.DefaultClient)
-	sc.BaseURL = srv.URL
+	sc := newTestScrapeClient(srv.URL)
 	r.Use(web.APIClient(sc))
 	r.GET("/lists/:user/:slug", v1.GetList)
 
@@ -108,5 +110,66 @@ func TestListFilmsSinglePage(t *testing.T) {
 	resp := &v1.APIResponse{}
 	err = json.Unmarshal(w.Body.Bytes(), &resp)
 	require.NoError(t, err)
-	require.Equal(t, 13, len(resp.Data.([]interface{})))
+	list := resp.Data.(map[string]interface{})
+	require.Equal(t, false, list["ranked"])
+	require.Equal(t, 13, len(list["entries"].([]interface{})))
+}
+
+func TestGetUserLists(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/dave/lists/page/") {
+			rp, err := os.Open(fmt.Sprintf("testdata/list/user-lists-%v.html", strings.Split(r.URL.Path, "/")[4]))
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	r := gin.Default()
+	sc := newTestScrapeClient(srv.URL)
+	r.Use(web.APIClient(sc))
+	r.GET("/users/:user/lists", v1.GetUserLists)
+
+	req, err := http.NewRequest(http.MethodGet, "/users/dave/lists", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ar v1.APIResponse
+	err = json.Unmarshal(w.Body.Bytes(), &ar)
+	require.NoError(t, err)
+	lists := ar.Data.([]interface{})
+	require.Equal(t, 4, len(lists))
+	require.Equal(t, "imdb-top-250", lists[0].(map[string]interface{})["slug"])
+}
+
+func TestGetListCatalog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	sc := newTestScrapeClient("http://127.0.0.1:0")
+	r.Use(web.APIClient(sc))
+	r.GET("/lists/catalog", v1.GetListCatalog)
+
+	req, err := http.NewRequest(http.MethodGet, "/lists/catalog?group=horror", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ar v1.APIResponse
+	err = json.Unmarshal(w.Body.Bytes(), &ar)
+	require.NoError(t, err)
+	group := ar.Data.(map[string]interface{})
+	require.Equal(t, "horror", group["name"])
+	require.NotEmpty(t, group["lists"])
+
+	req, err = http.NewRequest(http.MethodGet, "/lists/catalog?group=westerns", nil)
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
 }
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/gin-gonic/gin"
+)
+
+// GetUserReviews godoc
+// @Summary Get reviews per user
+// @Schemes
+// @Description Get a page of the reviews written by a user, newest first
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user path string true "user"
+// @Param page query int false "Page of reviews to fetch. Defaults to 1"
+// @Success 200 {object} APIResponse
+// @Router /users/{user}/reviews [get]
+func GetUserReviews(c *gin.Context) {
+	user := c.Param("user")
+	page, err := queryPage(c)
+	if err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	reviews, pagination, err := sc.Review.ByUser(c.Request.Context(), user, &letterboxd.ReviewOpt{FirstPage: page})
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data:       reviews,
+		Pagination: pagination,
+	})
+}
+
+// GetFilmReviews godoc
+// @Summary Get reviews per film
+// @Schemes
+// @Description Get a page of the reviews of a film
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param slug path string true "Film slug"
+// @Param page query int false "Page of reviews to fetch. Defaults to 1"
+// @Success 200 {object} APIResponse
+// @Router /films/{slug}/reviews [get]
+func GetFilmReviews(c *gin.Context) {
+	slug := c.Param("slug")
+	page, err := queryPage(c)
+	if err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	reviews, pagination, err := sc.Review.ByFilm(c.Request.Context(), slug, &letterboxd.ReviewOpt{FirstPage: page})
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data:       reviews,
+		Pagination: pagination,
+	})
+}
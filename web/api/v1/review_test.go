@@ -0,0 +1,59 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/drewstinnett/letterrestd/web"
+	v1 "github.com/drewstinnett/letterrestd/web/api/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUserReviews(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/someguy/films/reviews/page/") {
+			rp, err := os.Open(fmt.Sprintf("testdata/review/user-%v.html", strings.Split(r.URL.Path, "/")[5]))
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	r := gin.Default()
+	sc := newTestScrapeClient(srv.URL)
+	r.Use(web.APIClient(sc))
+	r.GET("/users/:user/reviews", v1.GetUserReviews)
+
+	req, err := http.NewRequest(http.MethodGet, "/users/someguy/reviews?page=2", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ar v1.APIResponse
+	err = json.Unmarshal(w.Body.Bytes(), &ar)
+	require.NoError(t, err)
+	reviews := ar.Data.([]interface{})
+	require.Equal(t, 1, len(reviews))
+	require.Equal(t, "A Christmas movie.", reviews[0].(map[string]interface{})["body_text"])
+	require.Equal(t, 2, ar.Pagination.CurrentPage)
+	require.True(t, ar.Pagination.IsLast)
+
+	for _, page := range []string{"two", "-1"} {
+		req, err = http.NewRequest(http.MethodGet, "/users/someguy/reviews?page="+page, nil)
+		require.NoError(t, err)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, page)
+	}
+}
@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/gin-gonic/gin"
+)
+
+// @BasePath /api/v1
+
+// Search godoc
+// @Summary Search for films
+// @Schemes
+// @Description Search for films by title. Results are ranked, best match first
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param q query string true "Title to search for"
+// @Param year query int false "Only return films released this year"
+// @Param page query int false "Page of results to fetch. Defaults to 1"
+// @Success 200 {object} APIResponse
+// @Router /search [get]
+func Search(c *gin.Context) {
+	q := c.Query("q")
+	if q == "" {
+		abortWithBadRequest(c, errors.New("q is required"))
+		return
+	}
+	opt := &letterboxd.SearchOpt{}
+	var err error
+	if opt.Year, err = queryInt(c, "year"); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	if opt.FirstPage, err = queryPage(c); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	results, pagination, err := sc.Film.Search(c.Request.Context(), q, opt)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data:       results,
+		Pagination: pagination,
+	})
+}
@@ -0,0 +1,58 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/drewstinnett/letterrestd/web"
+	v1 "github.com/drewstinnett/letterrestd/web/api/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() == "/search/films/the+thing/page/1/" {
+			rp, err := os.Open("testdata/search/the-thing-1.html")
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	r := gin.Default()
+	sc := newTestScrapeClient(srv.URL)
+	r.Use(web.APIClient(sc))
+	r.GET("/search", v1.Search)
+
+	req, err := http.NewRequest(http.MethodGet, "/search?q=the+thing", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ar v1.APIResponse
+	err = json.Unmarshal(w.Body.Bytes(), &ar)
+	require.NoError(t, err)
+	results := ar.Data.([]interface{})
+	require.Equal(t, 3, len(results))
+	first := results[0].(map[string]interface{})
+	require.Equal(t, float64(1), first["rank"])
+	require.Equal(t, "the-thing", first["film"].(map[string]interface{})["slug"])
+
+	for _, target := range []string{"/search", "/search?q=the+thing&page=-1"} {
+		req, err = http.NewRequest(http.MethodGet, target, nil)
+		require.NoError(t, err)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, target)
+	}
+}
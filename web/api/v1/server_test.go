@@ -0,0 +1,37 @@
+package v1_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newCompareServer serves the watched films and watchlists of alice and bob
+func newCompareServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		var fixture string
+		switch {
+		case len(parts) > 1 && parts[0] == "film":
+			fixture = "testdata/film/sweetback.html"
+		case len(parts) > 1 && (parts[0] == "alice" || parts[0] == "bob") && parts[1] == "films":
+			fixture = fmt.Sprintf("testdata/compare/%v-watched.html", parts[0])
+		case len(parts) > 1 && (parts[0] == "alice" || parts[0] == "bob") && parts[1] == "watchlist":
+			fixture = fmt.Sprintf("testdata/compare/%v-watchlist.html", parts[0])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rp, err := os.Open(fixture)
+		require.NoError(t, err)
+		defer rp.Close()
+		_, err = io.Copy(w, rp)
+		require.NoError(t, err)
+	}))
+}
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"fmt"
+
 	"github.com/drewstinnett/letterrestd/letterboxd"
 	"github.com/gin-gonic/gin"
 )
@@ -18,14 +20,158 @@ import (
 func GetWatched(c *gin.Context) {
 	user := c.Param("user")
 	sc := c.MustGet("client").(letterboxd.ScrapeClient)
-	films, _, err := sc.User.Watched(nil, user)
+	films, _, err := sc.User.Watched(c.Request.Context(), user)
 	if err != nil {
-		c.JSON(500, gin.H{
-			"message": err.Error(),
-		})
+		abortWithError(c, err)
 		return
 	}
 	c.IndentedJSON(200, APIResponse{
 		Data: films,
 	})
 }
+
+// GetDiary godoc
+// @Summary Get diary entries per user
+// @Schemes
+// @Description Get the diary of a user, newest first, optionally for a single year or month
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user path string true "user"
+// @Param year query int false "Only entries from this year"
+// @Param month query int false "Only entries from this month. Requires year"
+// @Success 200 {object} APIResponse
+// @Router /users/{user}/diary [get]
+func GetDiary(c *gin.Context) {
+	user := c.Param("user")
+	opt := &letterboxd.DiaryOpt{}
+	var err error
+	if opt.Year, err = queryInt(c, "year"); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	if opt.Month, err = queryInt(c, "month"); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	if err = opt.Validate(); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	entries, err := sc.User.Diary(c.Request.Context(), user, opt)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: entries,
+	})
+}
+
+// GetRatings godoc
+// @Summary Get ratings per user
+// @Schemes
+// @Description Get every film a user has rated, along with a histogram of their ratings
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user path string true "user"
+// @Success 200 {object} APIResponse
+// @Router /users/{user}/ratings [get]
+func GetRatings(c *gin.Context) {
+	user := c.Param("user")
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	ratings, err := sc.User.Ratings(c.Request.Context(), user)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: ratings,
+	})
+}
+
+// GetFollowing godoc
+// @Summary Get who a user follows
+// @Schemes
+// @Description Get the users that a user follows
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user path string true "user"
+// @Success 200 {object} APIResponse
+// @Router /users/{user}/following [get]
+func GetFollowing(c *gin.Context) {
+	user := c.Param("user")
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	users, err := sc.User.Following(c.Request.Context(), user)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: users,
+	})
+}
+
+// GetFollowers godoc
+// @Summary Get who follows a user
+// @Schemes
+// @Description Get the users that follow a user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user path string true "user"
+// @Success 200 {object} APIResponse
+// @Router /users/{user}/followers [get]
+func GetFollowers(c *gin.Context) {
+	user := c.Param("user")
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	users, err := sc.User.Followers(c.Request.Context(), user)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: users,
+	})
+}
+
+// GetSocialGraph godoc
+// @Summary Get the social graph around a user
+// @Schemes
+// @Description Walk out from a user through who they follow, a number of hops deep. Graphs stop growing at 500 users, and are marked as truncated
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user path string true "user"
+// @Param depth query int false "Hops out from the user. Defaults to 2"
+// @Param followers query bool false "Walk followers instead of who each user follows"
+// @Success 200 {object} APIResponse
+// @Router /users/{user}/graph [get]
+func GetSocialGraph(c *gin.Context) {
+	user := c.Param("user")
+	depth, err := queryInt(c, "depth")
+	if err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	if depth < 0 || depth > letterboxd.MaxSocialGraphDepth {
+		abortWithBadRequest(c, fmt.Errorf("depth must be between 1 and %d", letterboxd.MaxSocialGraphDepth))
+		return
+	}
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	g, err := sc.User.SocialGraph(c.Request.Context(), user, &letterboxd.SocialGraphOpt{
+		Depth:     depth,
+		Followers: c.Query("followers") == "true",
+		MaxUsers:  letterboxd.DefaultSocialGraphMaxUsers,
+	})
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: g,
+	})
+}
@@ -0,0 +1,97 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/drewstinnett/letterrestd/web"
+	v1 "github.com/drewstinnett/letterrestd/web/api/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDiary(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/someguy/films/diary/") {
+			paths = append(paths, r.URL.Path)
+			parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+			rp, err := os.Open(fmt.Sprintf("testdata/user/diary/%v.html", parts[len(parts)-1]))
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	r := gin.Default()
+	sc := newTestScrapeClient(srv.URL)
+	r.Use(web.APIClient(sc))
+	r.GET("/users/:user/diary", v1.GetDiary)
+
+	req, err := http.NewRequest(http.MethodGet, "/users/someguy/diary?year=2022&month=4", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ar v1.APIResponse
+	err = json.Unmarshal(w.Body.Bytes(), &ar)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(ar.Data.([]interface{})))
+	require.Equal(t, "/someguy/films/diary/for/2022/04/page/1/", paths[0])
+
+	for _, q := range []string{"month=4", "year=twenty", "year=2022&month=13"} {
+		req, err = http.NewRequest(http.MethodGet, "/users/someguy/diary?"+q, nil)
+		require.NoError(t, err)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, q)
+	}
+}
+
+func TestGetFollowing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/mondodrew/following/page/") {
+			rp, err := os.Open(fmt.Sprintf("testdata/user/following/%v.html", strings.Split(r.URL.Path, "/")[4]))
+			require.NoError(t, err)
+			defer rp.Close()
+			_, err = io.Copy(w, rp)
+			require.NoError(t, err)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	r := gin.Default()
+	sc := newTestScrapeClient(srv.URL)
+	r.Use(web.APIClient(sc))
+	r.GET("/users/:user/following", v1.GetFollowing)
+
+	req, err := http.NewRequest(http.MethodGet, "/users/mondodrew/following", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ar v1.APIResponse
+	err = json.Unmarshal(w.Body.Bytes(), &ar)
+	require.NoError(t, err)
+	users := ar.Data.([]interface{})
+	require.Equal(t, 4, len(users))
+	require.Equal(t, "dankmccoy", users[0].(map[string]interface{})["username"])
+
+	req, err = http.NewRequest(http.MethodGet, "/users/nobody/following", nil)
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"github.com/drewstinnett/letterrestd/analysis"
+	"github.com/drewstinnett/letterrestd/letterboxd"
+	"github.com/gin-gonic/gin"
+)
+
+// @BasePath /api/v1
+
+// GetWatchlistIntersection godoc
+// @Summary Films on several users' watchlists
+// @Schemes
+// @Description Films on every named user's watchlist, or on at least min_users of them, best average rating first. Handy for picking a film for movie night
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param users query string true "Comma separated usernames, like 'alice,bob,carol'"
+// @Param min_users query int false "Watchlists a film must be on. Defaults to all of them"
+// @Param min_runtime query int false "Shortest runtime, in minutes"
+// @Param max_runtime query int false "Longest runtime, in minutes"
+// @Param genre query []string false "Films must be in every one of these genres, like 'horror'" collectionFormat(multi)
+// @Param exclude_genre query []string false "Films must not be in any of these genres" collectionFormat(multi)
+// @Param exclude_watched query bool false "Leave out films any of the users has already watched. This scrapes every user's watched films, so is slow"
+// @Success 200 {object} APIResponse
+// @Router /watchlists/intersection [get]
+func GetWatchlistIntersection(c *gin.Context) {
+	users := queryUsers(c, "users")
+	if err := analysis.ValidateUsers(users); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	opt := &analysis.IntersectOpt{
+		Genres:         c.QueryArray("genre"),
+		ExcludeGenres:  c.QueryArray("exclude_genre"),
+		ExcludeWatched: c.Query("exclude_watched") == "true",
+	}
+	var err error
+	if opt.MinUsers, err = queryInt(c, "min_users"); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	if opt.MinRuntime, err = queryInt(c, "min_runtime"); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	if opt.MaxRuntime, err = queryInt(c, "max_runtime"); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	if err = opt.Validate(); err != nil {
+		abortWithBadRequest(c, err)
+		return
+	}
+	sc := c.MustGet("client").(letterboxd.ScrapeClient)
+	films, err := analysis.IntersectWatchlists(c.Request.Context(), &sc, users, opt)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.IndentedJSON(200, APIResponse{
+		Data: films,
+	})
+}
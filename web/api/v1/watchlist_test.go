@@ -0,0 +1,55 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drewstinnett/letterrestd/web"
+	v1 "github.com/drewstinnett/letterrestd/web/api/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWatchlistIntersection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := newCompareServer(t)
+	defer srv.Close()
+
+	r := gin.Default()
+	sc := newTestScrapeClient(srv.URL)
+	r.Use(web.APIClient(sc))
+	r.GET("/watchlists/intersection", v1.GetWatchlistIntersection)
+
+	tests := map[string][]string{
+		"users=alice,bob":                                         {"ran"},
+		"users=alice&users=bob&min_users=1":                       {"jaws", "ran"},
+		"users=alice,bob&min_users=1&exclude_watched=true":        {"ran"},
+		"users=alice,bob&genre=crime&exclude_genre=horror":        {"ran"},
+		"users=alice,bob&min_users=1&genre=horror":                {},
+		"users=alice,bob&min_users=1&min_runtime=1&max_runtime=1": {},
+	}
+	for q, want := range tests {
+		req, err := http.NewRequest(http.MethodGet, "/watchlists/intersection?"+q, nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, q)
+		var ar v1.APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &ar), q)
+		got := []string{}
+		for _, item := range ar.Data.([]interface{}) {
+			got = append(got, item.(map[string]interface{})["film"].(map[string]interface{})["slug"].(string))
+		}
+		require.ElementsMatch(t, want, got, q)
+	}
+
+	for _, q := range []string{"users=alice", "users=alice,bob&min_users=x", "users=alice,bob&min_runtime=100&max_runtime=90"} {
+		req, err := http.NewRequest(http.MethodGet, "/watchlists/intersection?"+q, nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, q)
+	}
+}
@@ -30,7 +30,7 @@ func NewRouter(r *RouterOpt) *gin.Engine {
 
 	var sc *letterboxd.ScrapeClient
 	if r.ScrapeClient == nil {
-		sc = letterboxd.NewScrapeClient(hc)
+		sc = letterboxd.NewScrapeClient(hc, nil)
 	} else {
 		sc = r.ScrapeClient
 	}
@@ -42,9 +42,26 @@ func NewRouter(r *RouterOpt) *gin.Engine {
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 	v1g := router.Group("/api/v1")
 	{
+		v1g.GET("/films", v1.BrowseFilms)
 		v1g.GET("/films/:slug", v1.GetFilm)
+		v1g.GET("/films/imdb/:id", v1.GetFilmByIMDB)
+		v1g.GET("/films/tmdb/:id", v1.GetFilmByTMDB)
+		v1g.GET("/films/:slug/credits", v1.GetFilmCredits)
+		v1g.GET("/films/:slug/reviews", v1.GetFilmReviews)
+		v1g.GET("/collections/:slug", v1.GetCollection)
+		v1g.GET("/lists/catalog", v1.GetListCatalog)
 		v1g.GET("/lists/:user/:slug", v1.GetList)
+		v1g.GET("/search", v1.Search)
+		v1g.GET("/compare", v1.Compare)
+		v1g.GET("/watchlists/intersection", v1.GetWatchlistIntersection)
 		v1g.GET("/users/:user/watched", v1.GetWatched)
+		v1g.GET("/users/:user/diary", v1.GetDiary)
+		v1g.GET("/users/:user/ratings", v1.GetRatings)
+		v1g.GET("/users/:user/reviews", v1.GetUserReviews)
+		v1g.GET("/users/:user/following", v1.GetFollowing)
+		v1g.GET("/users/:user/followers", v1.GetFollowers)
+		v1g.GET("/users/:user/graph", v1.GetSocialGraph)
+		v1g.GET("/users/:user/lists", v1.GetUserLists)
 	}
 
 	return router